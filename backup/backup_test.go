@@ -0,0 +1,75 @@
+package backup
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndRestore_File(t *testing.T) {
+	root := t.TempDir()
+	filePath := filepath.Join(root, "persist.estate.json")
+	err := ioutil.WriteFile(filePath, []byte("first"), 0644)
+	require.NoError(t, err)
+
+	snapshot1, err := CreateForFile(filePath)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filePath, []byte("second"), 0644)
+	require.NoError(t, err)
+	snapshot2, err := CreateForFile(filePath)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filePath, []byte("third"), 0644)
+	require.NoError(t, err)
+
+	snapshots, err := List(filePath)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	require.Equal(t, snapshot2.Name(), snapshots[0].Name())
+	require.Equal(t, snapshot1.Name(), snapshots[1].Name())
+
+	// snapshots of a file that shares the same prefix are not listed
+	otherSnapshot, err := CreateForFile(filePath + ".bak")
+	require.NoError(t, err)
+	require.Nil(t, otherSnapshot)
+
+	found, err := Find(filePath, snapshot1.Name())
+	require.NoError(t, err)
+	relPaths, err := Restore(*found)
+	require.NoError(t, err)
+	require.Equal(t, []string{"persist.estate.json"}, relPaths)
+	bs, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	require.Equal(t, "first", string(bs))
+
+	_, err = Find(filePath, "missing.zip")
+	require.ErrorAs(t, err, &ErrSnapshotNotFound{})
+}
+
+func TestCreateAndRestore_Folder(t *testing.T) {
+	root := t.TempDir()
+	names := []string{"persist.game.json", "persist.roster.json"}
+	for _, name := range names {
+		err := ioutil.WriteFile(filepath.Join(root, name), []byte(name), 0644)
+		require.NoError(t, err)
+	}
+
+	snapshot, err := CreateForFolder(root)
+	require.NoError(t, err)
+	relPaths, err := ListFiles(*snapshot)
+	require.NoError(t, err)
+	require.Equal(t, names, relPaths)
+
+	for _, name := range names {
+		err := ioutil.WriteFile(filepath.Join(root, name), []byte("corrupted"), 0644)
+		require.NoError(t, err)
+	}
+	_, err = Restore(*snapshot)
+	require.NoError(t, err)
+	for _, name := range names {
+		bs, err := ioutil.ReadFile(filepath.Join(root, name))
+		require.NoError(t, err)
+		require.Equal(t, name, string(bs))
+	}
+}
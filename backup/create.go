@@ -0,0 +1,130 @@
+package backup
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ResolveTarget returns the root folder and the label of the snapshots of a file or a folder.
+func ResolveTarget(targetPath string) (root string, label string, err error) {
+	absPath, err := filepath.Abs(targetPath)
+	if err != nil {
+		return "", "", err
+	}
+	info, err := os.Stat(absPath)
+	if err == nil && info.IsDir() {
+		return absPath, filepath.Base(absPath), nil
+	}
+	return filepath.Dir(absPath), filepath.Base(absPath), nil
+}
+
+// CreateForFile snapshots a single file. Nothing is done if the file does not exist, since there is nothing to lose.
+func CreateForFile(filePath string) (*Snapshot, error) {
+	root, label, err := ResolveTarget(filePath)
+	if err != nil {
+		err := errors.Wrap(err, "backup.CreateForFile error")
+		return nil, err
+	}
+	if _, err := os.Stat(filePath); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return Create(root, label, []string{label})
+}
+
+// CreateForFolder snapshots every file directly within a folder, which is how a profile folder is laid out.
+func CreateForFolder(folderPath string) (*Snapshot, error) {
+	root, label, err := ResolveTarget(folderPath)
+	if err != nil {
+		err := errors.Wrap(err, "backup.CreateForFolder error")
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir(root)
+	if err != nil {
+		err := errors.Wrap(err, "backup.CreateForFolder error")
+		return nil, err
+	}
+	relPaths := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Mode().IsRegular() {
+			relPaths = append(relPaths, entry.Name())
+		}
+	}
+	return Create(root, label, relPaths)
+}
+
+// Create archives the files at the relative paths within root.
+func Create(root string, label string, relPaths []string) (*Snapshot, error) {
+	folderPath := filepath.Join(root, FolderName)
+	if err := os.MkdirAll(folderPath, 0755); err != nil {
+		err := errors.Wrap(err, "backup.Create error creating backup folder")
+		return nil, err
+	}
+
+	snapshot := Snapshot{
+		Root:      root,
+		Label:     label,
+		CreatedAt: time.Now(),
+	}
+	// O_EXCL makes sure that snapshots that are created within the same millisecond do not override each other
+	archiveFile, err := os.OpenFile(filepath.Join(folderPath, snapshot.Name()), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	for errors.Is(err, os.ErrExist) {
+		snapshot.CreatedAt = snapshot.CreatedAt.Add(time.Millisecond)
+		archiveFile, err = os.OpenFile(filepath.Join(folderPath, snapshot.Name()), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	}
+	if err != nil {
+		err := errors.Wrap(err, "backup.Create error creating archive")
+		return nil, err
+	}
+	snapshot.Path = archiveFile.Name()
+
+	err = writeArchive(archiveFile, root, relPaths)
+	if errClose := archiveFile.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		_ = os.Remove(snapshot.Path)
+		err := errors.Wrap(err, "backup.Create error writing archive")
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
+func writeArchive(writer io.Writer, root string, relPaths []string) error {
+	zipWriter := zip.NewWriter(writer)
+	for _, relPath := range relPaths {
+		if err := addFile(zipWriter, root, relPath); err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
+
+func addFile(zipWriter *zip.Writer, root string, relPath string) error {
+	file, err := os.Open(filepath.Join(root, relPath))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(relPath)
+	header.Method = zip.Deflate
+	entryWriter, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entryWriter, file)
+	return err
+}
@@ -0,0 +1,49 @@
+// Package backup stores the code to snapshot files into timestamped zip archives before they are overwritten,
+// and to roll them back.
+//
+// Snapshots are kept in a hidden folder next to the backed up files:
+//
+//   - Backing up `profile/persist.estate.json` creates `profile/.darkest-savior-backups/persist.estate.json.<time>.zip`
+//   - Backing up the folder `profile` creates `profile/.darkest-savior-backups/profile.<time>.zip`
+//
+// The paths within an archive are relative to the folder that holds the backup folder, which is where they are
+// restored to.
+package backup
+
+import (
+	"fmt"
+	"time"
+)
+
+type (
+	Snapshot struct {
+		// Path is the path to the archive
+		Path string
+		// Root is the folder that the archived paths are relative to
+		Root string
+		// Label is the name of the backed up file or folder
+		Label     string
+		CreatedAt time.Time
+	}
+
+	ErrSnapshotNotFound struct {
+		Caller string
+		Name   string
+	}
+)
+
+const (
+	FolderName = ".darkest-savior-backups"
+	// TimeLayout is used within archive names; colons are avoided since Windows does not allow them.
+	TimeLayout = "2006-01-02T15-04-05.000"
+	Extension  = ".zip"
+)
+
+// Name returns the archive's file name, which is also what users type to pick a snapshot.
+func (r Snapshot) Name() string {
+	return r.Label + "." + r.CreatedAt.Format(TimeLayout) + Extension
+}
+
+func (r ErrSnapshotNotFound) Error() string {
+	return fmt.Sprintf(`%s: snapshot "%s" not found`, r.Caller, r.Name)
+}
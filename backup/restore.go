@@ -0,0 +1,150 @@
+package backup
+
+import (
+	"archive/zip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// List returns the snapshots of a file or a folder, the latest first.
+func List(targetPath string) ([]Snapshot, error) {
+	root, label, err := ResolveTarget(targetPath)
+	if err != nil {
+		err := errors.Wrap(err, "backup.List error")
+		return nil, err
+	}
+	entries, err := ioutil.ReadDir(filepath.Join(root, FolderName))
+	if errors.Is(err, os.ErrNotExist) {
+		return []Snapshot{}, nil
+	}
+	if err != nil {
+		err := errors.Wrap(err, "backup.List error")
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() ||
+			!strings.HasPrefix(name, label+".") ||
+			!strings.HasSuffix(name, Extension) {
+			continue
+		}
+		timeStr := strings.TrimSuffix(strings.TrimPrefix(name, label+"."), Extension)
+		createdAt, err := time.ParseInLocation(TimeLayout, timeStr, time.Local)
+		if err != nil {
+			// the label of another file shares the same prefix
+			continue
+		}
+		snapshots = append(
+			snapshots,
+			Snapshot{
+				Path:      filepath.Join(root, FolderName, name),
+				Root:      root,
+				Label:     label,
+				CreatedAt: createdAt,
+			},
+		)
+	}
+	sort.Slice(
+		snapshots,
+		func(i, j int) bool { return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt) },
+	)
+	return snapshots, nil
+}
+
+// Find returns the snapshot of a file or a folder by its name.
+func Find(targetPath string, name string) (*Snapshot, error) {
+	snapshots, err := List(targetPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.Name() == name {
+			return &snapshot, nil
+		}
+	}
+	return nil, ErrSnapshotNotFound{
+		Caller: "backup.Find",
+		Name:   name,
+	}
+}
+
+// ListFiles returns the relative paths of the files within a snapshot.
+func ListFiles(snapshot Snapshot) ([]string, error) {
+	zipReader, err := zip.OpenReader(snapshot.Path)
+	if err != nil {
+		err := errors.Wrap(err, "backup.ListFiles error")
+		return nil, err
+	}
+	defer zipReader.Close()
+	relPaths := make([]string, 0, len(zipReader.File))
+	for _, zipFile := range zipReader.File {
+		relPaths = append(relPaths, filepath.FromSlash(zipFile.Name))
+	}
+	return relPaths, nil
+}
+
+// Restore writes the files of a snapshot back to their places, and returns their relative paths.
+func Restore(snapshot Snapshot) ([]string, error) {
+	zipReader, err := zip.OpenReader(snapshot.Path)
+	if err != nil {
+		err := errors.Wrap(err, "backup.Restore error")
+		return nil, err
+	}
+	defer zipReader.Close()
+
+	relPaths := make([]string, 0, len(zipReader.File))
+	for _, zipFile := range zipReader.File {
+		relPath := filepath.FromSlash(zipFile.Name)
+		if filepath.IsAbs(relPath) || strings.HasPrefix(filepath.Clean(relPath), "..") {
+			err := errors.Errorf(`backup.Restore error: invalid path "%s" within archive`, zipFile.Name)
+			return relPaths, err
+		}
+		if err := restoreFile(zipFile, filepath.Join(snapshot.Root, relPath)); err != nil {
+			err := errors.Wrapf(err, `backup.Restore error restoring "%s"`, relPath)
+			return relPaths, err
+		}
+		relPaths = append(relPaths, relPath)
+	}
+	return relPaths, nil
+}
+
+func restoreFile(zipFile *zip.File, path string) error {
+	entryReader, err := zipFile.Open()
+	if err != nil {
+		return err
+	}
+	defer entryReader.Close()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// write to a temporary file first, so a failure midway does not leave a half written file
+	tempFile, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tempFile, entryReader)
+	if errClose := tempFile.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Chmod(tempFile.Name(), zipFile.Mode().Perm())
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tempFile.Name())
+		return err
+	}
+	return nil
+}
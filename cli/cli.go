@@ -1,19 +1,41 @@
 package cli
 
 import (
+	"bytes"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
 
 	"github.com/alexflint/go-arg"
 	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
 	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/ui"
 )
 
 type (
 	Args struct {
-		// Interactive *InteractiveCmd `arg:"subcommand:interactive"`
-		Convert *ConvertCmd `arg:"subcommand:convert"`
+		Interactive  *InteractiveCmd  `arg:"subcommand:interactive"`
+		Convert      *ConvertCmd      `arg:"subcommand:convert"`
+		ConvertDir   *ConvertDirCmd   `arg:"subcommand:convert-dir"`
+		Restore      *RestoreCmd      `arg:"subcommand:restore"`
+		Diff         *DiffCmd         `arg:"subcommand:diff"`
+		Patch        *PatchCmd        `arg:"subcommand:patch"`
+		Get          *GetCmd          `arg:"subcommand:get"`
+		Set          *SetCmd          `arg:"subcommand:set"`
+		Verify       *VerifyCmd       `arg:"subcommand:verify"`
+		LearnSchema  *LearnSchemaCmd  `arg:"subcommand:learn-schema"`
+		JSONSchema   *JSONSchemaCmd   `arg:"subcommand:json-schema"`
+		Unresolved   *UnresolvedCmd   `arg:"subcommand:unresolved"`
+		HarvestNames *HarvestNamesCmd `arg:"subcommand:harvest-names"`
+		Profiles     *ProfilesCmd     `arg:"subcommand:profiles"`
+		Roster       *RosterCmd       `arg:"subcommand:roster"`
+		Schema       string           `help:"path to the schema file of data types that replaces the embedded one" placeholder:"schema.json"`
+		Names        []string         `arg:"separate" help:"path to an extra dictionary of names (one per line) to unhash integers with; can be repeated" placeholder:"names.txt"`
+		Unhash       string           `help:"which integers are turned into names: off, safe (only the fields of the schema), or aggressive" default:"safe" placeholder:"safe"`
 	}
 	InteractiveCmd struct{}
 	ConvertCmd     struct {
@@ -21,10 +43,85 @@ type (
 		// the underlying library has some limitation on displaying help and placeholder
 		// too long placeholder force help to be put on another line, which looks really ugly
 		// that is why text is really sparse for the arguments, even though I wanted it to be clearer
-		From  string `arg:"required" help:"path to source file" placeholder:"persist.json"`
-		To    string `arg:"required" help:"path to destination file" placeholder:"file.json"`
-		Force bool   `help:"overwrite the destination file"`
-		Debug bool   `help:"enable debugging on destination file"`
+		From        string `arg:"required" help:"path to source file" placeholder:"persist.json"`
+		To          string `arg:"required" help:"path to destination file" placeholder:"file.json"`
+		Force       bool   `help:"overwrite the destination file"`
+		Debug       bool   `help:"enable debugging on destination file"`
+		Annotations string `help:"path to the sidecar file of data types; written when decoding, read when encoding" placeholder:"file.annotations"`
+		Format      string `help:"format of the non-DSON file: json, yaml, or json5" default:"json" placeholder:"json"`
+	}
+	ConvertDirCmd struct {
+		From        string `arg:"required" help:"path to source folder" placeholder:"sample_dson"`
+		To          string `arg:"required" help:"path to destination folder" placeholder:"sample_json"`
+		Force       bool   `help:"overwrite the destination files"`
+		Debug       bool   `help:"enable debugging on destination files"`
+		Annotations bool   `help:"keep sidecar files that hold data types next to the JSON files"`
+	}
+	RestoreCmd struct {
+		Target   string `arg:"required" help:"path to the backed up file or folder" placeholder:"persist.json"`
+		Snapshot string `help:"name of the snapshot to restore; list the snapshots if empty" placeholder:"name"`
+	}
+	DiffCmd struct {
+		Old string `arg:"required" help:"path to the older DSON file" placeholder:"persist.json"`
+		New string `arg:"required" help:"path to the newer DSON file" placeholder:"persist.json"`
+	}
+	PatchCmd struct {
+		File  string `arg:"required" help:"path to the DSON file to patch" placeholder:"persist.json"`
+		Patch string `arg:"required" help:"path to the JSON Patch (RFC 6902) file" placeholder:"patch.json"`
+	}
+	GetCmd struct {
+		File string `arg:"required" help:"path to the DSON file" placeholder:"persist.json"`
+		Path string `arg:"required" help:"dot-separated path of the fields; * matches any name" placeholder:"base_root.*"`
+	}
+	SetCmd struct {
+		File  string `arg:"required" help:"path to the DSON file to edit" placeholder:"persist.json"`
+		Path  string `arg:"required" help:"dot-separated path of the fields; * matches any name" placeholder:"base_root.*"`
+		Value string `arg:"required" help:"new value; vectors are written as JSON arrays" placeholder:"value"`
+	}
+	VerifyCmd struct {
+		File        string `arg:"required" help:"path to the DSON file to verify" placeholder:"persist.json"`
+		Annotations string `help:"path to the sidecar file of data types to verify with" placeholder:"file.annotations"`
+	}
+	LearnSchemaCmd struct {
+		From  []string `arg:"required" help:"paths to DSON files, or folders of them" placeholder:"saves"`
+		To    string   `arg:"required" help:"path to the learned schema file" placeholder:"schema.json"`
+		Force bool     `help:"overwrite the destination file"`
+	}
+	JSONSchemaCmd struct {
+		From  []string `arg:"required" help:"paths to DSON files, or folders of them" placeholder:"saves"`
+		To    string   `arg:"required" help:"path to the folder of JSON Schema files, one per save kind" placeholder:"schemas"`
+		Force bool     `help:"overwrite the destination files"`
+	}
+	UnresolvedCmd struct {
+		From []string `arg:"required" help:"paths to DSON files, or folders of them" placeholder:"saves"`
+	}
+	HarvestNamesCmd struct {
+		From  []string `arg:"required" help:"paths to folders of the game or of mods" placeholder:"DarkestDungeon"`
+		To    string   `arg:"required" help:"path to the augmented names file" placeholder:"names.txt"`
+		Force bool     `help:"overwrite the destination file"`
+	}
+	ProfilesCmd struct {
+		Roots []string `arg:"positional" help:"paths to folders to look for profiles within; Steam's and GOG's usual folders if empty" placeholder:"ROOT"`
+	}
+	RosterCmd struct {
+		List *RosterListCmd `arg:"subcommand:list"`
+		Show *RosterShowCmd `arg:"subcommand:show"`
+		Edit *RosterEditCmd `arg:"subcommand:edit"`
+	}
+	RosterListCmd struct {
+		File string `arg:"required" help:"path to the roster file" placeholder:"persist.roster.json"`
+	}
+	RosterShowCmd struct {
+		File string `arg:"required" help:"path to the roster file" placeholder:"persist.roster.json"`
+		ID   string `arg:"positional,required" help:"ID of the hero, as listed by roster list" placeholder:"ID"`
+	}
+	RosterEditCmd struct {
+		File        string   `arg:"required" help:"path to the roster file" placeholder:"persist.roster.json"`
+		ID          string   `arg:"positional,required" help:"ID of the hero, as listed by roster list" placeholder:"ID"`
+		Stress      *float64 `help:"new stress, from 0 to 200" placeholder:"0"`
+		HP          *float64 `arg:"--hp" help:"new current HP" placeholder:"20"`
+		AddQuirk    []string `arg:"--add-quirk,separate" help:"ID of a quirk to add; can be repeated" placeholder:"clotter"`
+		RemoveQuirk []string `arg:"--remove-quirk,separate" help:"ID of a quirk to remove; can be repeated" placeholder:"unquiet_mind"`
 	}
 )
 
@@ -42,7 +139,9 @@ func (Args) Description() string {
 }
 
 func StartInteractive() {
-	println("Not implemented! Please just use  convert  for now.")
+	if err := ui.Start(); err != nil {
+		println("Error happened running the interactive mode: " + err.Error())
+	}
 }
 
 func CheckExistence(path string) bool {
@@ -54,6 +153,11 @@ func CheckExistence(path string) bool {
 }
 
 func StartConverting(args ConvertCmd) {
+	format := dson.Format(args.Format)
+	if !lo.Contains(dson.Formats, format) {
+		println("Unsupported format: " + args.Format)
+		return
+	}
 	if !CheckExistence(args.From) {
 		println("Source file does not exist!")
 		return
@@ -63,45 +167,133 @@ func StartConverting(args ConvertCmd) {
 		println("Explicit --force is needed to make sure that you paid attention not to overwriting the actual DSON file in your folder.")
 		return
 	}
-	fileBytes, err := ioutil.ReadFile(args.From)
+	snapshot, err := backup.CreateForFile(args.To)
 	if err != nil {
-		println("Error happened reading file")
+		println("Error happened backing up the destination file: " + err.Error())
 		return
 	}
+	if snapshot != nil {
+		println("Backed up the destination file to: " + snapshot.Path)
+	}
+	if err := ConvertFile(args.From, args.To, args.Annotations, format, args.Debug); err != nil {
+		println(err.Error())
+		return
+	}
+	println("Done converting. Please check your result file at: " + args.To)
+}
+
+// ConvertFile converts a DSON file to JSON (or YAML), or a JSON (or YAML) file to DSON, depending on the source
+// file's magic number. If annotationsPath is not empty, the annotations are written there when decoding, and read
+// from there when encoding.
+func ConvertFile(from string, to string, annotationsPath string, format dson.Format, debug bool) error {
+	fileBytes, err := ioutil.ReadFile(from)
+	if err != nil {
+		return errors.New("Error happened reading file")
+	}
 
-	if dson.IsDSONFile(fileBytes[:4]) {
-		resultBytes, err := dson.DecodeDSON(fileBytes, args.Debug)
-		if err != nil {
-			println("Error happened decoding DSON to JSON")
-			return
+	if len(fileBytes) >= 4 && dson.IsDSONFile(fileBytes[:4]) {
+		decoder := dson.NewDecoder(bytes.NewReader(fileBytes))
+		decoder.SetDebug(debug)
+		decoder.SetFormat(format)
+		annotationsBuffer := bytes.Buffer{}
+		decoder.SetAnnotationsWriter(&annotationsBuffer)
+		resultBuffer := bytes.Buffer{}
+		if err := decoder.DecodeJSON(&resultBuffer); err != nil {
+			return errors.New("Error happened decoding DSON to " + strings.ToUpper(string(format)))
 		}
-		if err := ioutil.WriteFile(args.To, resultBytes, 0644); err != nil {
-			println("Error happened writing to file at: " + args.To)
-			return
+		if err := ioutil.WriteFile(to, resultBuffer.Bytes(), 0644); err != nil {
+			return errors.New("Error happened writing to file at: " + to)
+		}
+		if annotationsPath != "" {
+			if err := ioutil.WriteFile(annotationsPath, annotationsBuffer.Bytes(), 0644); err != nil {
+				return errors.New("Error happened writing annotations to: " + annotationsPath)
+			}
 		}
 	} else {
-		resultBytes, err := dson.EncodeJSON(fileBytes)
-		if err != nil {
-			println("Error happened encoding JSON to DSON")
-			return
+		resultBuffer := bytes.Buffer{}
+		encoder := dson.NewEncoder(&resultBuffer)
+		encoder.SetFormat(format)
+		if annotationsPath != "" {
+			annotationsFile, err := os.Open(annotationsPath)
+			if err != nil {
+				return errors.New("Error happened reading annotations file at: " + annotationsPath)
+			}
+			annotations, err := dson.ReadAnnotations(annotationsFile)
+			_ = annotationsFile.Close()
+			if err != nil {
+				return errors.New("Error happened reading annotations file at: " + annotationsPath)
+			}
+			encoder.SetAnnotations(annotations)
+		}
+		if err := encoder.EncodeJSON(bytes.NewReader(fileBytes)); err != nil {
+			return errors.New("Error happened encoding " + strings.ToUpper(string(format)) + " to DSON")
 		}
-		err = ioutil.WriteFile(args.To, resultBytes, 0644)
+		err = ioutil.WriteFile(to, resultBuffer.Bytes(), 0644)
 		if err != nil {
-			println("Error happened writing output to: " + args.To)
-			return
+			return errors.New("Error happened writing output to: " + to)
 		}
 	}
-	println("Done converting. Please check your result file at: " + args.To)
+	return nil
 }
 
 func Start() {
 	args := Args{}
 	arg.MustParse(&args)
 
-	if args.Convert != nil {
+	if args.Schema != "" {
+		if err := UseSchemaFile(args.Schema); err != nil {
+			println(err.Error())
+			return
+		}
+	}
+	unhashMode := dfield.UnhashMode(args.Unhash)
+	if !lo.Contains(dfield.UnhashModes, unhashMode) {
+		println("Unsupported unhash mode: " + args.Unhash)
+		return
+	}
+	dfield.UseUnhashMode(unhashMode)
+	for _, namesPath := range args.Names {
+		addedCount, err := UseNameFile(namesPath)
+		if err != nil {
+			println(err.Error())
+			return
+		}
+		println(fmt.Sprintf("Added %d names from: %s", addedCount, namesPath))
+	}
+	PrintCollisions()
+
+	if args.Interactive != nil {
+		StartInteractive()
+	} else if args.Convert != nil {
 		StartConverting(*args.Convert)
+	} else if args.ConvertDir != nil {
+		StartConvertingDir(*args.ConvertDir)
+	} else if args.Restore != nil {
+		StartRestoring(*args.Restore)
+	} else if args.Diff != nil {
+		StartDiffing(*args.Diff)
+	} else if args.Patch != nil {
+		StartPatching(*args.Patch)
+	} else if args.Get != nil {
+		StartGetting(*args.Get)
+	} else if args.Set != nil {
+		StartSetting(*args.Set)
+	} else if args.Verify != nil {
+		StartVerifying(*args.Verify)
+	} else if args.LearnSchema != nil {
+		StartLearningSchema(*args.LearnSchema)
+	} else if args.JSONSchema != nil {
+		StartExportingJSONSchema(*args.JSONSchema)
+	} else if args.Unresolved != nil {
+		StartReportingUnresolved(*args.Unresolved)
+	} else if args.HarvestNames != nil {
+		StartHarvestingNames(*args.HarvestNames)
+	} else if args.Profiles != nil {
+		StartListingProfiles(*args.Profiles)
+	} else if args.Roster != nil {
+		StartManagingRoster(*args.Roster)
 	} else {
 		println("Convert from DSON to JSON and vice versa are available.")
-		println("Please use the functionality by retyping your command with `convert` at the end.")
+		println("Please use the functionality by retyping your command with `convert` or `convert-dir` at the end.")
 	}
 }
@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+)
+
+// AnnotationsExtension is appended to the path of a JSON file to get the path of its annotations. It is different
+// from ".json", so the annotations are not mistaken for files to convert.
+const AnnotationsExtension = ".annotations"
+
+// CollectFilePaths returns the relative paths of the JSON files (DSON files use the same extension)
+// within the folder and its sub-folders.
+func CollectFilePaths(folderPath string) ([]string, error) {
+	relPaths := make([]string, 0)
+	err := filepath.WalkDir(
+		folderPath,
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() || !strings.EqualFold(filepath.Ext(path), ".json") {
+				return nil
+			}
+			relPath, err := filepath.Rel(folderPath, path)
+			if err != nil {
+				return err
+			}
+			relPaths = append(relPaths, relPath)
+			return nil
+		},
+	)
+	return relPaths, err
+}
+
+// hasDSONHeader tells whether the file at path starts with the DSON magic number, which means it is decoded rather
+// than encoded.
+func hasDSONHeader(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+	magicNumber := make([]byte, 4)
+	if _, err := io.ReadFull(file, magicNumber); err != nil {
+		return false
+	}
+	return dson.IsDSONFile(magicNumber)
+}
+
+func StartConvertingDir(args ConvertDirCmd) {
+	info, err := os.Stat(args.From)
+	if err != nil || !info.IsDir() {
+		println("Source folder does not exist!")
+		return
+	}
+	relPaths, err := CollectFilePaths(args.From)
+	if err != nil {
+		println("Error happened reading folder: " + err.Error())
+		return
+	}
+	if len(relPaths) == 0 {
+		println("There is no file to convert within the source folder.")
+		return
+	}
+	existedRelPaths := make([]string, 0)
+	for _, relPath := range relPaths {
+		if CheckExistence(filepath.Join(args.To, relPath)) {
+			existedRelPaths = append(existedRelPaths, relPath)
+		}
+	}
+	if len(existedRelPaths) > 0 && !args.Force {
+		for _, relPath := range existedRelPaths {
+			println("Destination file existed: " + filepath.Join(args.To, relPath))
+		}
+		println("Please type the command again with --force to allow overwriting!")
+		println("Explicit --force is needed to make sure that you paid attention not to overwriting the actual DSON files in your folder.")
+		return
+	}
+	if len(existedRelPaths) > 0 {
+		root, label, err := backup.ResolveTarget(args.To)
+		if err != nil {
+			println("Error happened backing up the destination folder: " + err.Error())
+			return
+		}
+		snapshot, err := backup.Create(root, label, existedRelPaths)
+		if err != nil {
+			println("Error happened backing up the destination folder: " + err.Error())
+			return
+		}
+		println("Backed up the destination files to: " + snapshot.Path)
+	}
+
+	failedCount := 0
+	for _, relPath := range relPaths {
+		from := filepath.Join(args.From, relPath)
+		to := filepath.Join(args.To, relPath)
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			println("Error happened creating folder: " + filepath.Dir(to))
+			failedCount += 1
+			continue
+		}
+		annotationsPath := ""
+		if args.Annotations {
+			// the annotations are kept next to the JSON file,
+			// which is the destination when decoding, and the source when encoding (if there is one)
+			if hasDSONHeader(from) {
+				annotationsPath = to + AnnotationsExtension
+			} else if CheckExistence(from + AnnotationsExtension) {
+				annotationsPath = from + AnnotationsExtension
+			}
+		}
+		if err := ConvertFile(from, to, annotationsPath, dson.FormatJSON, args.Debug); err != nil {
+			println(from + ": " + err.Error())
+			failedCount += 1
+			continue
+		}
+		println("Converted: " + from + " -> " + to)
+	}
+	if failedCount > 0 {
+		println("Done converting with errors. Please check the messages above.")
+		return
+	}
+	println("Done converting. Please check your result folder at: " + args.To)
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+)
+
+func StartDiffing(args DiffCmd) {
+	oldFile, err := ReadDSONFile(args.Old)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	newFile, err := ReadDSONFile(args.New)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+
+	differences := dson.Diff(*oldFile, *newFile)
+	if len(differences) == 0 {
+		println("No difference found.")
+		return
+	}
+	for _, difference := range differences {
+		fmt.Println(difference.String())
+	}
+}
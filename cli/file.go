@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// ReadDSONFile reads and decodes a DSON file.
+func ReadDSONFile(path string) (*dstruct.Struct, error) {
+	return readDSONFile(path, dstruct.ToStructuredFile)
+}
+
+// ReadDSONFileLeniently works like ReadDSONFile, but does not fail on fields whose data cannot be inferred.
+func ReadDSONFileLeniently(path string) (*dstruct.Struct, error) {
+	return readDSONFile(path, dstruct.ToStructuredFileLeniently)
+}
+
+func readDSONFile(path string, decode func([]byte) (*dstruct.Struct, error)) (*dstruct.Struct, error) {
+	fileBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.New("Error happened reading file at: " + path)
+	}
+	if len(fileBytes) < 4 || !dson.IsDSONFile(fileBytes[:4]) {
+		return nil, errors.New("File is not a DSON file: " + path)
+	}
+	file, err := decode(fileBytes)
+	if err != nil {
+		return nil, errors.New("Error happened decoding DSON file at: " + path)
+	}
+	return file, nil
+}
+
+// WriteDSONFile backs up the file at path, then overwrites it with the encoded struct.
+func WriteDSONFile(path string, file dstruct.Struct) error {
+	snapshot, err := backup.CreateForFile(path)
+	if err != nil {
+		return errors.New("Error happened backing up the file: " + err.Error())
+	}
+	if snapshot != nil {
+		println("Backed up the file to: " + snapshot.Path)
+	}
+	if err := ioutil.WriteFile(path, dstruct.EncodeStruct(file), 0644); err != nil {
+		return errors.New("Error happened writing to file at: " + path)
+	}
+	return nil
+}
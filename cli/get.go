@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+)
+
+func StartGetting(args GetCmd) {
+	file, err := ReadDSONFile(args.File)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	flatFields := dson.Query(*file, args.Path)
+	if len(flatFields) == 0 {
+		println("No field matched the path: " + args.Path)
+		return
+	}
+	for _, flatField := range flatFields {
+		inferences := flatField.Field.Inferences
+		fmt.Printf(
+			"%s (%s): %s\n",
+			flatField.PathString(), inferences.DataType, dson.FormatValue(inferences.DataType, inferences.Data),
+		)
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dhash"
+)
+
+// CollectHarvestableFilePaths returns the paths of the files within the folders (and their sub-folders) that can have
+// names (see dhash.IsHarvestable).
+func CollectHarvestableFilePaths(folderPaths []string) ([]string, error) {
+	filePaths := make([]string, 0)
+	for _, folderPath := range folderPaths {
+		err := filepath.WalkDir(
+			folderPath,
+			func(path string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !entry.IsDir() && dhash.IsHarvestable(path) {
+					filePaths = append(filePaths, path)
+				}
+				return nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return filePaths, nil
+}
+
+func StartHarvestingNames(args HarvestNamesCmd) {
+	if CheckExistence(args.To) && !args.Force {
+		println("Destination file existed. Please type the command again with --force to allow overwriting!")
+		return
+	}
+	filePaths, err := CollectHarvestableFilePaths(args.From)
+	if err != nil {
+		println("Error happened reading the sources: " + err.Error())
+		return
+	}
+
+	harvestedCount := 0
+	newNames := make([]string, 0)
+	newHashes := make(map[int32]bool)
+	for _, filePath := range filePaths {
+		fileBytes, err := ioutil.ReadFile(filePath)
+		if err != nil {
+			println("Skipped: Error happened reading file at: " + filePath)
+			continue
+		}
+		// saves are DSON files with extension ".json" as well, and their names are hashed already
+		if len(fileBytes) >= 4 && dson.IsDSONFile(fileBytes[:4]) {
+			continue
+		}
+		for _, name := range dhash.HarvestNames(filePath, fileBytes) {
+			harvestedCount += 1
+			hash := dhash.HashString(name)
+			if _, ok := dhash.NameByHash[hash]; ok || newHashes[hash] {
+				continue
+			}
+			newHashes[hash] = true
+			newNames = append(newNames, name)
+		}
+	}
+	sort.Strings(newNames)
+
+	// the known names keep their order, so the result can be compared with the embedded list
+	names := append(dhash.KnownNames(), newNames...)
+	snapshot, err := backup.CreateForFile(args.To)
+	if err != nil {
+		println("Error happened backing up the destination file: " + err.Error())
+		return
+	}
+	if snapshot != nil {
+		println("Backed up the destination file to: " + snapshot.Path)
+	}
+	if err := ioutil.WriteFile(args.To, []byte(strings.Join(names, "\n")+"\n"), 0644); err != nil {
+		println("Error happened writing to file at: " + args.To)
+		return
+	}
+	println(fmt.Sprintf(
+		"Harvested %d names from %d files; %d of them are new. Please check your result at: %s",
+		harvestedCount, len(filePaths), len(newNames), args.To,
+	))
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// JSONSchemaExtension is appended to the save kind to get the name of its JSON Schema file.
+const JSONSchemaExtension = ".schema.json"
+
+func StartExportingJSONSchema(args JSONSchemaCmd) {
+	filePaths, err := CollectDSONFilePaths(args.From)
+	if err != nil {
+		println("Error happened reading the sources: " + err.Error())
+		return
+	}
+	filesByKind := make(map[string][]dstruct.Struct)
+	kinds := make([]string, 0)
+	for _, filePath := range filePaths {
+		file, err := ReadDSONFile(filePath)
+		if err != nil {
+			println("Skipped: " + err.Error())
+			continue
+		}
+		kind := dson.SaveKind(filePath)
+		if !lo.Contains(kinds, kind) {
+			kinds = append(kinds, kind)
+		}
+		filesByKind[kind] = append(filesByKind[kind], *file)
+	}
+	if len(kinds) == 0 {
+		println("There is no DSON file to export the JSON Schema from.")
+		return
+	}
+
+	toPaths := lo.Map(kinds, func(kind string, _ int) string { return filepath.Join(args.To, kind+JSONSchemaExtension) })
+	existedToPaths := lo.Filter(toPaths, func(toPath string, _ int) bool { return CheckExistence(toPath) })
+	if len(existedToPaths) > 0 && !args.Force {
+		for _, toPath := range existedToPaths {
+			println("Destination file existed: " + toPath)
+		}
+		println("Please type the command again with --force to allow overwriting!")
+		return
+	}
+	if err := os.MkdirAll(args.To, 0755); err != nil {
+		println("Error happened creating folder: " + args.To)
+		return
+	}
+
+	for i, kind := range kinds {
+		schema := dson.ExportJSONSchema(kind, filesByKind[kind])
+		schemaBytes, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			println("Error happened encoding the JSON Schema of " + kind + ": " + err.Error())
+			return
+		}
+		snapshot, err := backup.CreateForFile(toPaths[i])
+		if err != nil {
+			println("Error happened backing up the destination file: " + err.Error())
+			return
+		}
+		if snapshot != nil {
+			println("Backed up the destination file to: " + snapshot.Path)
+		}
+		if err := ioutil.WriteFile(toPaths[i], schemaBytes, 0644); err != nil {
+			println("Error happened writing to file at: " + toPaths[i])
+			return
+		}
+		println("Exported: " + toPaths[i])
+	}
+	println("Done exporting. Please check your result folder at: " + args.To)
+}
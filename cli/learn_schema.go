@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+)
+
+// CollectDSONFilePaths returns the paths of the files, and of the JSON files within the folders (and their
+// sub-folders) from the given paths.
+func CollectDSONFilePaths(paths []string) ([]string, error) {
+	filePaths := make([]string, 0)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			filePaths = append(filePaths, path)
+			continue
+		}
+		relPaths, err := CollectFilePaths(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, relPath := range relPaths {
+			filePaths = append(filePaths, filepath.Join(path, relPath))
+		}
+	}
+	return filePaths, nil
+}
+
+func StartLearningSchema(args LearnSchemaCmd) {
+	if CheckExistence(args.To) && !args.Force {
+		println("Destination file existed. Please type the command again with --force to allow overwriting!")
+		return
+	}
+	filePaths, err := CollectDSONFilePaths(args.From)
+	if err != nil {
+		println("Error happened reading the sources: " + err.Error())
+		return
+	}
+
+	learner := dson.NewSchemaLearner()
+	learnedCount := 0
+	for _, filePath := range filePaths {
+		// the files that the schema in use does not fit are what to learn from
+		file, err := ReadDSONFileLeniently(filePath)
+		if err != nil {
+			println("Skipped: " + err.Error())
+			continue
+		}
+		learner.Observe(*file)
+		learnedCount += 1
+	}
+	if learnedCount == 0 {
+		println("There is no DSON file to learn from.")
+		return
+	}
+
+	conflicts := learner.Conflicts()
+	for _, conflict := range conflicts {
+		fmt.Println(conflict.String())
+	}
+	schemaBytes, err := json.MarshalIndent(learner.Schema(), "", "  ")
+	if err != nil {
+		println("Error happened encoding the schema: " + err.Error())
+		return
+	}
+	snapshot, err := backup.CreateForFile(args.To)
+	if err != nil {
+		println("Error happened backing up the destination file: " + err.Error())
+		return
+	}
+	if snapshot != nil {
+		println("Backed up the destination file to: " + snapshot.Path)
+	}
+	if err := ioutil.WriteFile(args.To, schemaBytes, 0644); err != nil {
+		println("Error happened writing to file at: " + args.To)
+		return
+	}
+	println(fmt.Sprintf("Learned from %d files with %d conflicts.", learnedCount, len(conflicts)))
+	if len(conflicts) > 0 {
+		println("The conflicted paths above are left out. Please check them, and add them to the schema file by hand if needed.")
+	}
+	println("Done learning. Please check your schema file at: " + args.To)
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dhash"
+)
+
+// UseNameFile merges the names of the dictionary at path (one name per line) into the embedded ones, and returns the
+// number of names that were not known before.
+func UseNameFile(path string) (int, error) {
+	namesBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, errors.New("Error happened reading names file at: " + path)
+	}
+	return dhash.AddNames(dhash.ParseNames(string(namesBytes))), nil
+}
+
+// PrintCollisions prints the hashes that are shared by more than one known name.
+func PrintCollisions() {
+	hashes := lo.Keys(dhash.CollisionsByHash)
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i] < hashes[j] })
+	for _, hash := range hashes {
+		println(fmt.Sprintf(
+			"Hash collision: %d is the hash of %s; %s is used",
+			hash, strings.Join(dhash.CollisionsByHash[hash], ", "), dhash.NameByHash[hash],
+		))
+	}
+}
+
+func StartReportingUnresolved(args UnresolvedCmd) {
+	filePaths, err := CollectDSONFilePaths(args.From)
+	if err != nil {
+		println("Error happened reading the sources: " + err.Error())
+		return
+	}
+
+	unresolvedCount := 0
+	ambiguousCount := 0
+	distinctValues := make(map[int32]bool)
+	for _, filePath := range filePaths {
+		file, err := ReadDSONFile(filePath)
+		if err != nil {
+			println("Skipped: " + err.Error())
+			continue
+		}
+		for _, unresolvedHash := range dson.FindUnresolvedHashes(*file) {
+			fmt.Println(filePath + ": " + unresolvedHash.String())
+			distinctValues[unresolvedHash.Value] = true
+			unresolvedCount += 1
+		}
+		for _, ambiguousHash := range dson.FindAmbiguousHashes(*file) {
+			fmt.Println(filePath + ": " + ambiguousHash.String())
+			ambiguousCount += 1
+		}
+	}
+	println(fmt.Sprintf("Found %d unresolved hashes (%d distinct values).", unresolvedCount, len(distinctValues)))
+	if ambiguousCount > 0 {
+		println(fmt.Sprintf("Found %d ambiguous hashes; please check which of the names is the right one.", ambiguousCount))
+	}
+}
@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+)
+
+func StartPatching(args PatchCmd) {
+	file, err := ReadDSONFile(args.File)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	patchBytes, err := ioutil.ReadFile(args.Patch)
+	if err != nil {
+		println("Error happened reading patch file at: " + args.Patch)
+		return
+	}
+	patchedFile, err := dson.Patch(*file, patchBytes)
+	if err != nil {
+		println("Error happened applying patch: " + err.Error())
+		return
+	}
+	if err := WriteDSONFile(args.File, *patchedFile); err != nil {
+		println(err.Error())
+		return
+	}
+	println("Done patching. Please check your result file at: " + args.File)
+}
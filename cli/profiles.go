@@ -0,0 +1,23 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/thanhnguyen2187/darkest-savior/profile"
+)
+
+func StartListingProfiles(args ProfilesCmd) {
+	roots := args.Roots
+	if len(roots) == 0 {
+		roots = profile.DefaultRoots()
+	}
+	summaries, err := profile.Discover(roots)
+	if err != nil {
+		println("Error happened discovering profiles: " + err.Error())
+		return
+	}
+	for _, summary := range summaries {
+		fmt.Printf("%s\t%s\t%s\t%s\n", summary.Path, summary.EstateName, summary.GameMode, summary.DateTime)
+	}
+	println(fmt.Sprintf("Found %d profiles.", len(summaries)))
+}
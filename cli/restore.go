@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+)
+
+func StartRestoring(args RestoreCmd) {
+	if args.Snapshot == "" {
+		snapshots, err := backup.List(args.Target)
+		if err != nil {
+			println("Error happened listing snapshots: " + err.Error())
+			return
+		}
+		if len(snapshots) == 0 {
+			println("There is no snapshot of: " + args.Target)
+			return
+		}
+		println("Snapshots of " + args.Target + " (latest first):")
+		for _, snapshot := range snapshots {
+			println("  " + snapshot.Name())
+		}
+		println("Please type the command again with --snapshot <name> to restore one of them.")
+		return
+	}
+
+	snapshot, err := backup.Find(args.Target, args.Snapshot)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	// the current state is backed up as well, so restoring can be undone
+	relPaths, err := backup.ListFiles(*snapshot)
+	if err != nil {
+		println("Error happened reading snapshot: " + err.Error())
+		return
+	}
+	existedRelPaths := make([]string, 0, len(relPaths))
+	for _, relPath := range relPaths {
+		if CheckExistence(filepath.Join(snapshot.Root, relPath)) {
+			existedRelPaths = append(existedRelPaths, relPath)
+		}
+	}
+	if len(existedRelPaths) > 0 {
+		currentSnapshot, err := backup.Create(snapshot.Root, snapshot.Label, existedRelPaths)
+		if err != nil {
+			println("Error happened backing up the current files: " + err.Error())
+			return
+		}
+		println("Backed up the current files to: " + currentSnapshot.Path)
+	}
+
+	restoredRelPaths, err := backup.Restore(*snapshot)
+	for _, relPath := range restoredRelPaths {
+		println("Restored: " + relPath)
+	}
+	if err != nil {
+		println("Error happened restoring snapshot: " + err.Error())
+		return
+	}
+	println("Done restoring snapshot: " + snapshot.Name())
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/saves"
+	"github.com/thanhnguyen2187/darkest-savior/saves/roster"
+)
+
+func StartManagingRoster(args RosterCmd) {
+	if args.List != nil {
+		StartListingHeroes(*args.List)
+	} else if args.Show != nil {
+		StartShowingHero(*args.Show)
+	} else if args.Edit != nil {
+		StartEditingHero(*args.Edit)
+	} else {
+		println("Please choose one of the roster commands: list, show, or edit.")
+	}
+}
+
+// ReadRoster reads and decodes a roster file (`persist.roster.json`).
+func ReadRoster(path string) (*roster.Roster, error) {
+	file, err := ReadDSONFile(path)
+	if err != nil {
+		return nil, err
+	}
+	heroRoster, err := roster.FromStruct(*file)
+	if err != nil {
+		return nil, errors.New("Error happened reading the heroes of: " + path + ": " + err.Error())
+	}
+	return heroRoster, nil
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatQuirks(quirks []roster.Quirk) string {
+	return strings.Join(
+		lo.Map(quirks, func(quirk roster.Quirk, _ int) string { return quirk.ID }),
+		", ",
+	)
+}
+
+func StartListingHeroes(args RosterListCmd) {
+	heroRoster, err := ReadRoster(args.File)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "ID\tNAME\tCLASS\tLEVEL\tRESOLVE XP\tSTRESS\tHP\tQUIRKS")
+	for _, hero := range heroRoster.Heroes {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\n",
+			hero.ID, hero.Actor.Name, hero.Class, hero.Level(), hero.ResolveXP,
+			formatNumber(hero.Stress), formatNumber(hero.Actor.CurrentHP), formatQuirks(hero.Quirks),
+		)
+	}
+	_ = writer.Flush()
+}
+
+// PrintHero prints the details of a hero, with the quirks, skills, and trinkets in tables.
+func PrintHero(hero roster.Hero) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "ID:\t%s\n", hero.ID)
+	_, _ = fmt.Fprintf(writer, "Name:\t%s\n", hero.Actor.Name)
+	_, _ = fmt.Fprintf(writer, "Class:\t%s\n", hero.Class)
+	_, _ = fmt.Fprintf(writer, "Level:\t%d\n", hero.Level())
+	_, _ = fmt.Fprintf(writer, "Resolve XP:\t%d\n", hero.ResolveXP)
+	_, _ = fmt.Fprintf(writer, "Stress:\t%s\n", formatNumber(hero.Stress))
+	_, _ = fmt.Fprintf(writer, "HP:\t%s\n", formatNumber(hero.Actor.CurrentHP))
+	_, _ = fmt.Fprintf(writer, "Weapon/armour rank:\t%d/%d\n", hero.WeaponRank, hero.ArmourRank)
+	_ = writer.Flush()
+
+	fmt.Println()
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "QUIRK\tLOCKED\tNEW\tMISSIONS")
+	for _, quirk := range hero.Quirks {
+		_, _ = fmt.Fprintf(writer, "%s\t%t\t%t\t%d\n", quirk.ID, quirk.IsLocked, quirk.IsNew, quirk.MissionCount)
+	}
+	_ = writer.Flush()
+
+	fmt.Println()
+	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "SKILL\tKIND\tLEVEL")
+	for _, skill := range hero.Skills.Combat {
+		_, _ = fmt.Fprintf(writer, "%s\tcombat\t%d\n", skill.ID, skill.Level)
+	}
+	for _, skill := range hero.Skills.Camping {
+		_, _ = fmt.Fprintf(writer, "%s\tcamping\t%d\n", skill.ID, skill.Level)
+	}
+	_ = writer.Flush()
+
+	if len(hero.Trinkets) > 0 {
+		fmt.Println()
+		fmt.Println("Trinkets: " + strings.Join(
+			lo.Map(hero.Trinkets, func(trinket saves.Item, _ int) string { return trinket.ID }),
+			", ",
+		))
+	}
+}
+
+func StartShowingHero(args RosterShowCmd) {
+	heroRoster, err := ReadRoster(args.File)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	hero := heroRoster.FindHero(args.ID)
+	if hero == nil {
+		println("Hero not found: " + args.ID)
+		return
+	}
+	PrintHero(*hero)
+}
+
+// EditHero applies the changes of the arguments to the hero, and stops at the first invalid one.
+func EditHero(hero *roster.Hero, args RosterEditCmd) error {
+	if args.Stress != nil {
+		if err := hero.SetStress(*args.Stress); err != nil {
+			return err
+		}
+	}
+	if args.HP != nil {
+		if err := hero.SetCurrentHP(*args.HP); err != nil {
+			return err
+		}
+	}
+	for _, quirkID := range args.RemoveQuirk {
+		if err := hero.RemoveQuirk(quirkID); err != nil {
+			return err
+		}
+	}
+	for _, quirkID := range args.AddQuirk {
+		if err := hero.AddQuirk(quirkID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func StartEditingHero(args RosterEditCmd) {
+	if args.Stress == nil && args.HP == nil && len(args.AddQuirk) == 0 && len(args.RemoveQuirk) == 0 {
+		println("Nothing to edit. Please type the command again with at least one of --stress, --hp, --add-quirk, or --remove-quirk.")
+		return
+	}
+	heroRoster, err := ReadRoster(args.File)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	hero := heroRoster.FindHero(args.ID)
+	if hero == nil {
+		println("Hero not found: " + args.ID)
+		return
+	}
+	if err := EditHero(hero, args); err != nil {
+		println("Error happened editing the hero; nothing was written: " + err.Error())
+		return
+	}
+	file, err := roster.ToStruct(*heroRoster)
+	if err != nil {
+		println("Error happened encoding the roster: " + err.Error())
+		return
+	}
+	if err := WriteDSONFile(args.File, *file); err != nil {
+		println(err.Error())
+		return
+	}
+	PrintHero(*hero)
+	println("Done editing. Please check your result file at: " + args.File)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/saves/roster"
+)
+
+func copySampleRoster(t *testing.T) string {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.roster.json")
+	require.NoError(t, err)
+	filePath := filepath.Join(t.TempDir(), "persist.roster.json")
+	require.NoError(t, ioutil.WriteFile(filePath, fileBytes, 0644))
+	return filePath
+}
+
+func TestStartEditingHero(t *testing.T) {
+	filePath := copySampleRoster(t)
+	stress := float64(0)
+	hp := float64(20)
+	StartEditingHero(
+		RosterEditCmd{
+			File:        filePath,
+			ID:          "56",
+			Stress:      &stress,
+			HP:          &hp,
+			AddQuirk:    []string{"clotter"},
+			RemoveQuirk: []string{"unquiet_mind"},
+		},
+	)
+
+	fileBytes, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+
+	// the outer entry `heroes.56` holds the embedded file as bytes, which must have been encoded again
+	rawDataFields := dson.Query(*file, "base_root.heroes.56.hero_file_data.raw_data")
+	require.Len(t, rawDataFields, 1)
+	embeddedFile, err := dstruct.ToStructuredFile(rawDataFields[0].Field.Inferences.RawDataStripped[4:])
+	require.NoError(t, err)
+	heroBaseRoot := "base_root."
+	assert.Equal(t, float32(0), dson.Query(*embeddedFile, heroBaseRoot+"m_Stress")[0].Field.Inferences.Data)
+	assert.Equal(t, float32(20), dson.Query(*embeddedFile, heroBaseRoot+"actor.current_hp")[0].Field.Inferences.Data)
+	assert.Len(t, dson.Query(*embeddedFile, heroBaseRoot+"quirks.clotter"), 1)
+	assert.Empty(t, dson.Query(*embeddedFile, heroBaseRoot+"quirks.unquiet_mind"))
+
+	heroRoster, err := roster.FromStruct(*file)
+	require.NoError(t, err)
+	require.Len(t, heroRoster.Heroes, 20)
+	assert.Equal(t, float64(0), heroRoster.FindHero("56").Stress)
+	assert.Equal(t, float64(40), heroRoster.FindHero("22").Stress)
+
+	snapshots, err := backup.List(filePath)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+}
+
+func TestStartEditingHero_NothingToEdit(t *testing.T) {
+	filePath := copySampleRoster(t)
+	expectedBytes, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+
+	StartEditingHero(RosterEditCmd{File: filePath, ID: "56"})
+
+	actualBytes, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	assert.Equal(t, expectedBytes, actualBytes)
+	snapshots, err := backup.List(filePath)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+}
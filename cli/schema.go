@@ -0,0 +1,22 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+)
+
+// UseSchemaFile replaces the embedded schema of data types with the one at path.
+func UseSchemaFile(path string) error {
+	schemaBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.New("Error happened reading schema file at: " + path)
+	}
+	schema, err := dfield.ReadSchema(schemaBytes)
+	if err != nil {
+		return errors.New("Error happened reading schema file: " + err.Error())
+	}
+	dfield.UseSchema(*schema)
+	return nil
+}
@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+)
+
+func StartSetting(args SetCmd) {
+	file, err := ReadDSONFile(args.File)
+	if err != nil {
+		println(err.Error())
+		return
+	}
+	updatedFile, updatedFlatFields, err := dson.Set(*file, args.Path, args.Value)
+	if err != nil {
+		println("Error happened setting value: " + err.Error())
+		return
+	}
+	if err := WriteDSONFile(args.File, *updatedFile); err != nil {
+		println(err.Error())
+		return
+	}
+	for _, flatField := range updatedFlatFields {
+		inferences := flatField.Field.Inferences
+		fmt.Printf(
+			"%s (%s): %s\n",
+			flatField.PathString(), inferences.DataType, dson.FormatValue(inferences.DataType, inferences.Data),
+		)
+	}
+	println("Done setting. Please check your result file at: " + args.File)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func StartVerifying(args VerifyCmd) {
+	fileBytes, err := ioutil.ReadFile(args.File)
+	if err != nil {
+		println("Error happened reading file at: " + args.File)
+		return
+	}
+	if len(fileBytes) < 4 || !dson.IsDSONFile(fileBytes[:4]) {
+		println("File is not a DSON file: " + args.File)
+		return
+	}
+	annotations := dstruct.Annotations(nil)
+	if args.Annotations != "" {
+		annotationsBytes, err := ioutil.ReadFile(args.Annotations)
+		if err != nil {
+			println("Error happened reading annotations file at: " + args.Annotations)
+			return
+		}
+		annotations, err = dson.ReadAnnotations(bytes.NewReader(annotationsBytes))
+		if err != nil {
+			println(err.Error())
+			return
+		}
+	}
+
+	divergences, err := dson.Verify(fileBytes, annotations)
+	if err != nil {
+		println("Error happened verifying file: " + err.Error())
+		return
+	}
+	if len(divergences) == 0 {
+		println("Verified. Converting the file to JSON and back gives the exact original bytes.")
+		return
+	}
+	for _, divergence := range divergences {
+		fmt.Println(divergence.String())
+	}
+	println("Converting the file to JSON and back does not give the original bytes. Please check the divergences above.")
+}
@@ -0,0 +1,56 @@
+package dson
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func TestAnnotatedRoundTrip(t *testing.T) {
+	filePaths, err := filepath.Glob("../sample_dson/*.json")
+	require.NoError(t, err)
+	for _, filePath := range filePaths {
+		fileBytes, err := ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+		jsonBytes, annotationsBytes, err := DecodeDSONAnnotated(fileBytes, false)
+		require.NoError(t, err, filePath)
+		encodedBytes, err := EncodeJSONAnnotated(jsonBytes, annotationsBytes)
+		require.NoError(t, err, filePath)
+		require.Equal(t, fileBytes, encodedBytes, filePath)
+	}
+}
+
+func TestAnnotatedDataType(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.estate.json")
+	require.NoError(t, err)
+	jsonBytes, annotationsBytes, err := DecodeDSONAnnotated(fileBytes, false)
+	require.NoError(t, err)
+	annotations, err := ReadAnnotations(bytes.NewReader(annotationsBytes))
+	require.NoError(t, err)
+
+	const goldPointer = "/base_root/wallet/0/amount"
+	const versionPointer = "/base_root/version"
+	require.Equal(t, dfield.DataTypeInt, annotations[goldPointer].DataType)
+	// an annotated data type is used even if the value looks like another one
+	annotations[goldPointer] = dstruct.Annotation{DataType: dfield.DataTypeFloat}
+	// and it is ignored if the value does not fit anymore
+	jsonBytes = bytes.Replace(jsonBytes, []byte(`"version": 34`), []byte(`"version": "edited"`), 1)
+
+	buffer := bytes.Buffer{}
+	encoder := NewEncoder(&buffer)
+	encoder.SetAnnotations(annotations)
+	require.NoError(t, encoder.EncodeJSON(bytes.NewReader(jsonBytes)))
+
+	file, err := dstruct.ToStructuredFile(buffer.Bytes())
+	require.NoError(t, err)
+	golds := Query(*file, "base_root.wallet.0.amount")
+	require.Equal(t, dfield.EncodeValueFloat(float64(18275)), golds[0].Field.Inferences.RawDataStripped)
+	versions := Query(*file, "base_root.version")
+	require.Equal(t, "edited", versions[0].Field.Inferences.Data)
+	require.Equal(t, dstruct.ToPointer([]string{"base_root", "version"}), versionPointer)
+}
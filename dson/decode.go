@@ -1,24 +1,30 @@
 package dson
 
 import (
-	"encoding/json"
-
-	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"bytes"
 )
 
-func DecodeDSON(bytes []byte, debug bool) ([]byte, error) {
-	decodedFile, err := dstruct.ToStructuredFile(bytes)
-	if err != nil {
+func DecodeDSON(fileBytes []byte, debug bool) ([]byte, error) {
+	decoder := NewDecoder(bytes.NewReader(fileBytes))
+	decoder.SetDebug(debug)
+
+	buffer := bytes.Buffer{}
+	if err := decoder.DecodeJSON(&buffer); err != nil {
 		return nil, err
 	}
+	return buffer.Bytes(), nil
+}
 
-	if debug {
-		decodedFileBytes := make([]byte, 0)
-		decodedFileBytes, err = json.MarshalIndent(decodedFile, "", "  ")
-		return decodedFileBytes, nil
-	}
+// DecodeDSONAnnotated works like DecodeDSON, but also returns the annotations of the file as a sidecar JSON file.
+func DecodeDSONAnnotated(fileBytes []byte, debug bool) ([]byte, []byte, error) {
+	decoder := NewDecoder(bytes.NewReader(fileBytes))
+	decoder.SetDebug(debug)
 
-	decodedMap := dstruct.ToLinkedHashMap(*decodedFile)
-	decodedBytes, err := json.MarshalIndent(decodedMap, "", "  ")
-	return decodedBytes, nil
+	buffer := bytes.Buffer{}
+	annotationsBuffer := bytes.Buffer{}
+	decoder.SetAnnotationsWriter(&annotationsBuffer)
+	if err := decoder.DecodeJSON(&buffer); err != nil {
+		return nil, nil, err
+	}
+	return buffer.Bytes(), annotationsBuffer.Bytes(), nil
 }
@@ -0,0 +1,140 @@
+package dfield
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dhash"
+)
+
+// CandidateDataTypes lists the data types that the raw data (with its padded bytes stripped) fits, in the order of
+// preference: char, bool, int, float, string, two_bool, int_vector, float_vector, string_vector, and two_int. Since a
+// DSON file does not store the data types, the raw data alone can fit several of them, but the candidates of many
+// fields that share the same path narrow down to the correct one.
+//
+// A 4-byte value only stops fitting an integer if it clearly looks like a float (see inspectWord), and stops fitting
+// a float if it cannot be one that the game would store. Zero bytes fit both of them, and an empty vector fits any
+// kind of vector.
+func CandidateDataTypes(rawDataStripped []byte) []DataType {
+	rawLen := len(rawDataStripped)
+	candidates := make([]DataType, 0)
+	add := func(dataType DataType) {
+		candidates = append(candidates, dataType)
+	}
+
+	if rawLen == 1 {
+		b := rawDataStripped[0]
+		if 0x20 <= b && b <= 0x7E {
+			add(DataTypeChar)
+		}
+		if b == 0 || b == 1 {
+			add(DataTypeBool)
+		}
+		return candidates
+	}
+	if rawLen < 4 {
+		return candidates
+	}
+
+	if rawLen == 4 {
+		canBeFloat, looksLikeFloat := inspectWord(rawDataStripped)
+		if !looksLikeFloat {
+			add(DataTypeInt)
+		}
+		if canBeFloat {
+			add(DataTypeFloat)
+		}
+	}
+	if fitsString(rawDataStripped) {
+		add(DataTypeString)
+	}
+	if rawLen == 8 && lo.EveryBy(
+		[][]byte{rawDataStripped[:4], rawDataStripped[4:]},
+		func(word []byte) bool { return binary.LittleEndian.Uint32(word) <= 1 },
+	) {
+		add(DataTypeTwoBool)
+	}
+	if rawLen%4 == 0 && int(binary.LittleEndian.Uint32(rawDataStripped[:4])) == rawLen/4-1 {
+		words := lo.Chunk(rawDataStripped[4:], 4)
+		if !lo.SomeBy(words, func(word []byte) bool { _, looksLikeFloat := inspectWord(word); return looksLikeFloat }) {
+			add(DataTypeIntVector)
+		}
+		if len(words) == 0 || lo.EveryBy(words, func(word []byte) bool { canBeFloat, _ := inspectWord(word); return canBeFloat }) {
+			add(DataTypeFloatVector)
+		}
+	}
+	if fitsStringVector(rawDataStripped) {
+		add(DataTypeStringVector)
+	}
+	if rawLen == 8 {
+		add(DataTypeTwoInt)
+	}
+	return candidates
+}
+
+// inspectWord tells whether the 4 bytes can be a float that the game would store, and whether they also look like
+// one rather than an integer. Small integers are tiny (or not a number) as floats, so they cannot be floats. The
+// floats of the game are mostly "short" numbers like 37, 0.15, or 12.5, while a hash or a timestamp that can be read
+// as a float in range is rarely that short.
+func inspectWord(word []byte) (canBeFloat bool, looksLikeFloat bool) {
+	bits := binary.LittleEndian.Uint32(word)
+	if bits == 0 {
+		return true, false
+	}
+	if _, isHash := dhash.NameByHash[int32(bits)]; isHash {
+		return false, false
+	}
+	value := math.Float32frombits(bits)
+	absValue := math.Abs(float64(value))
+	if math.IsNaN(absValue) || absValue < 1e-4 || absValue > 1e7 {
+		return false, false
+	}
+	// the shortest representation that gives back the same float, like "1.5e+01"
+	mantissa := strings.Split(strconv.FormatFloat(float64(value), 'e', -1, 32), "e")[0]
+	significantDigits := len(strings.Trim(mantissa, "-."))
+	if strings.Contains(mantissa, ".") {
+		significantDigits -= 1
+	}
+	return true, significantDigits <= 5
+}
+
+// fitsString tells whether the raw data is a length, and then the exact number of bytes with the last one being zero.
+func fitsString(rawDataStripped []byte) bool {
+	length, ok := readString(rawDataStripped)
+	return ok && length == len(rawDataStripped)
+}
+
+func fitsStringVector(rawDataStripped []byte) bool {
+	if len(rawDataStripped) < 4 {
+		return false
+	}
+	count := binary.LittleEndian.Uint32(rawDataStripped[:4])
+	cursor := 4
+	for i := uint32(0); i < count; i++ {
+		length, ok := readString(rawDataStripped[cursor:])
+		if !ok {
+			return false
+		}
+		cursor += length
+	}
+	return cursor == len(rawDataStripped)
+}
+
+// readString reads a string at the start of the bytes, and returns the number of bytes that it takes.
+func readString(bs []byte) (int, bool) {
+	if len(bs) < 5 {
+		return 0, false
+	}
+	length := readStringLength(bs)
+	if length < 5 || length > len(bs) || bs[length-1] != 0 {
+		return 0, false
+	}
+	return length, true
+}
+
+func readStringLength(bs []byte) int {
+	return 4 + int(binary.LittleEndian.Uint32(bs[:4]))
+}
@@ -0,0 +1,32 @@
+package dfield
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCandidateDataTypes(t *testing.T) {
+	cases := []struct {
+		rawDataStripped []byte
+		expected        []DataType
+	}{
+		{[]byte{1}, []DataType{DataTypeBool}},
+		{[]byte{'A'}, []DataType{DataTypeChar}},
+		{EncodeValueInt(0), []DataType{DataTypeInt, DataTypeFloat, DataTypeIntVector, DataTypeFloatVector, DataTypeStringVector}},
+		{EncodeValueInt(37), []DataType{DataTypeInt}},
+		{EncodeValueInt(-1), []DataType{DataTypeInt}},
+		{EncodeValueFloat(float64(37)), []DataType{DataTypeFloat}},
+		{EncodeValueFloat(1.0 / 3), []DataType{DataTypeInt, DataTypeFloat}},
+		{EncodeValueString("###crusader"), []DataType{DataTypeInt}},
+		{EncodeValueString("crusader"), []DataType{DataTypeString}},
+		{EncodeValueTwoBool([]bool{true, false}), []DataType{DataTypeTwoBool, DataTypeIntVector, DataTypeFloatVector, DataTypeTwoInt}},
+		{EncodeValueTwoInt([]float64{3, 4}), []DataType{DataTypeTwoInt}},
+		{EncodeValueIntVector([]float64{3, 4}), []DataType{DataTypeIntVector}},
+		{EncodeValueFloatVector([]float64{0.5, 4}), []DataType{DataTypeFloatVector}},
+		{EncodeValueStringVector([]string{"a", "bc"}), []DataType{DataTypeStringVector}},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, CandidateDataTypes(c.rawDataStripped), c.rawDataStripped)
+	}
+}
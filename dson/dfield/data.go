@@ -38,6 +38,11 @@ type (
 		Expected string
 		Actual   int
 	}
+	ErrInvalidValue struct {
+		Caller   string
+		DataType DataType
+		Value    string
+	}
 )
 
 const (
@@ -62,6 +67,9 @@ const (
 	DataTypeObject       = DataType("object")
 
 	FieldNameRevision = "__revision_dont_touch"
+	// FieldNameDuplicationSuffix marks the keys of fields whose names are duplicated within the same object.
+	// Also see: ToKey.
+	FieldNameDuplicationSuffix = "__duplicated_dont_touch_"
 )
 
 func (r ErrRevisionNotFound) Error() string {
@@ -87,3 +95,11 @@ func (r ErrInvalidDataLengthCustom) Error() string {
 	)
 	return msg
 }
+
+func (r ErrInvalidValue) Error() string {
+	msg := fmt.Sprintf(
+		`%s: invalid value "%s" for data type "%s"`,
+		r.Caller, r.Value, r.DataType,
+	)
+	return msg
+}
@@ -49,6 +49,17 @@ func DecodeField(reader *lbytes.Reader, meta2Entry dmeta2.Entry) (*DataField, er
 }
 
 func DecodeFields(reader *lbytes.Reader, meta2Blocks []dmeta2.Entry) ([]DataField, error) {
+	return decodeFields(reader, meta2Blocks, false)
+}
+
+// DecodeFieldsLeniently works like DecodeFields, but a field whose data cannot be inferred is kept with the unknown
+// data type, instead of failing the whole decoding. It is meant for inspecting the raw data of files that the schema
+// does not fit yet.
+func DecodeFieldsLeniently(reader *lbytes.Reader, meta2Blocks []dmeta2.Entry) ([]DataField, error) {
+	return decodeFields(reader, meta2Blocks, true)
+}
+
+func decodeFields(reader *lbytes.Reader, meta2Blocks []dmeta2.Entry, lenient bool) ([]DataField, error) {
 	fields := make([]DataField, 0, len(meta2Blocks))
 	for _, meta2Block := range meta2Blocks {
 		field, err := DecodeField(reader, meta2Block)
@@ -69,6 +80,11 @@ func DecodeFields(reader *lbytes.Reader, meta2Blocks []dmeta2.Entry) ([]DataFiel
 	)
 	for i, field := range fields {
 		data, err := InferData(field.Inferences.DataType, field.Inferences.RawDataStripped)
+		if err != nil && lenient {
+			field.Inferences.DataType = DataTypeUnknown
+			fields[i] = field
+			continue
+		}
 		if err != nil {
 			err := errors.Wrap(err, "dfield.DecodeFields error")
 			return nil, err
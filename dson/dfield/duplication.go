@@ -0,0 +1,56 @@
+package dfield
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ToKey returns the key of a field within its parent object. It is the field name for the first occurrence, and the
+// field name with a numbered suffix for the next ones (starting at 2), since a JSON object cannot have the same key
+// twice. For example, the second `slay_the_baron` becomes `slay_the_baron__duplicated_dont_touch_2`.
+func ToKey(fieldName string, occurrence int) string {
+	if occurrence <= 1 {
+		return fieldName
+	}
+	return fieldName + FieldNameDuplicationSuffix + strconv.Itoa(occurrence)
+}
+
+// FromKey strips the suffix that ToKey might have added and returns the actual field name.
+func FromKey(key string) string {
+	index := strings.LastIndex(key, FieldNameDuplicationSuffix)
+	if index == -1 {
+		return key
+	}
+	if _, err := strconv.Atoi(key[index+len(FieldNameDuplicationSuffix):]); err != nil {
+		return key
+	}
+	return key[:index]
+}
+
+// InferKeys returns the key of each field within its parent object (see ToKey).
+func InferKeys(fields []DataField) []string {
+	type sibling struct {
+		parentIndex int
+		name        string
+	}
+	occurrences := map[sibling]int{}
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		s := sibling{parentIndex: field.Inferences.ParentIndex, name: field.Name}
+		occurrences[s] += 1
+		keys = append(keys, ToKey(field.Name, occurrences[s]))
+	}
+	return keys
+}
+
+// InferKey returns the key of the field at index within its parent object (see ToKey).
+func InferKey(index int, fields []DataField) string {
+	field := fields[index]
+	occurrence := 1
+	for _, sibling := range fields[:index] {
+		if sibling.Name == field.Name && sibling.Inferences.ParentIndex == field.Inferences.ParentIndex {
+			occurrence += 1
+		}
+	}
+	return ToKey(field.Name, occurrence)
+}
@@ -89,8 +89,20 @@ func EncodeValueIntVector(value any) []byte {
 	panic("EncodeValueIntVector unreachable code")
 }
 
+// toFloat64Vector is needed since JSON unmarshalling produces []any instead of []float64 for arrays of numbers.
+func toFloat64Vector(value any) []float64 {
+	valueFloat64Vector, ok := value.([]float64)
+	if ok {
+		return valueFloat64Vector
+	}
+	return lo.Map(
+		value.([]any),
+		func(valueAny any, _ int) float64 { return valueAny.(float64) },
+	)
+}
+
 func EncodeValueFloatVector(value any) []byte {
-	valueFloat64Vector := value.([]float64)
+	valueFloat64Vector := toFloat64Vector(value)
 	bs := EncodeValueInt(len(valueFloat64Vector))
 	bs = lo.Reduce(
 		valueFloat64Vector,
@@ -167,7 +179,7 @@ func EncodeValueTwoBool(value any) []byte {
 }
 
 func EncodeValueTwoInt(value any) []byte {
-	valueIntVector := value.([]float64)
+	valueIntVector := toFloat64Vector(value)
 	return append(
 		EncodeValueInt(valueIntVector[0]),
 		EncodeValueInt(valueIntVector[1])...,
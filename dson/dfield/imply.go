@@ -2,6 +2,7 @@ package dfield
 
 import (
 	"math"
+	"strings"
 
 	"github.com/iancoleman/orderedmap"
 	"github.com/samber/lo"
@@ -82,3 +83,55 @@ func ImplyDataType(fieldName string, hierarchyPath []string, value any) DataType
 	}
 	return dataType
 }
+
+// AcceptsValue tells whether the JSON-shaped value can be encoded as the data type. It is used to check if a known
+// data type (from annotations, for example) still fits a value that might have been edited.
+func AcceptsValue(dataType DataType, value any) bool {
+	isIntegral := func(value any) bool {
+		valueFloat64, ok := value.(float64)
+		return ok && math.Trunc(valueFloat64) == valueFloat64
+	}
+	isHashedName := func(value any) bool {
+		valueStr, ok := value.(string)
+		return ok && strings.HasPrefix(valueStr, "###")
+	}
+	allOf := func(value any, predicate func(any) bool) bool {
+		values, ok := value.([]any)
+		return ok && lo.EveryBy(values, predicate)
+	}
+	countOf := func(value any) int {
+		values, _ := value.([]any)
+		return len(values)
+	}
+
+	switch dataType {
+	case DataTypeBool:
+		_, ok := value.(bool)
+		return ok
+	case DataTypeChar:
+		valueStr, ok := value.(string)
+		return ok && len(valueStr) == 1
+	case DataTypeInt:
+		return isIntegral(value)
+	case DataTypeFloat:
+		_, ok := value.(float64)
+		return ok
+	case DataTypeString:
+		_, ok := value.(string)
+		return ok
+	case DataTypeIntVector, DataTypeHybridVector:
+		return allOf(value, func(item any) bool { return isIntegral(item) || isHashedName(item) })
+	case DataTypeFloatVector:
+		return allOf(value, func(item any) bool { _, ok := item.(float64); return ok })
+	case DataTypeStringVector:
+		return allOf(value, func(item any) bool { _, ok := item.(string); return ok })
+	case DataTypeTwoInt:
+		return countOf(value) == 2 && allOf(value, isIntegral)
+	case DataTypeTwoBool:
+		return countOf(value) == 2 && allOf(value, func(item any) bool { _, ok := item.(bool); return ok })
+	case DataTypeObject:
+		_, ok := value.(orderedmap.OrderedMap)
+		return ok
+	}
+	return false
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/thanhnguyen2187/darkest-savior/dson/dhash"
 	"github.com/thanhnguyen2187/darkest-savior/dson/dheader"
 	"github.com/thanhnguyen2187/darkest-savior/dson/dmeta2"
-	"github.com/thanhnguyen2187/darkest-savior/match"
 )
 
 func InferUsingMeta2Entry(rawData []byte, meta2Entry dmeta2.Entry) Inferences {
@@ -37,129 +36,44 @@ func InferUsingMeta2Entry(rawData []byte, meta2Entry dmeta2.Entry) Inferences {
 	}
 }
 
+// InferHierarchyPath returns the keys (see InferKey) from the root object to the field at index. The keys are the
+// field names, except for duplicated ones, which makes every path unique.
 func InferHierarchyPath(index int, fields []DataField) []string {
-	// TODO: create a cache function if there is need for optimization
-	fieldName := fields[index].Name
+	key := InferKey(index, fields)
 	parentIndex := fields[index].Inferences.ParentIndex
 	if parentIndex == -1 {
-		return []string{fields[index].Name}
+		return []string{key}
 	}
-	return append(InferHierarchyPath(parentIndex, fields), fieldName)
+	return append(InferHierarchyPath(parentIndex, fields), key)
 }
 
 func InferHierarchyPaths(fields []DataField) []DataField {
-	fieldsCopy := lo.Map(
-		fields,
-		func(t DataField, i int) DataField {
-			t.Inferences.HierarchyPath = InferHierarchyPath(i, fields)
-			return t
-		},
-	)
+	// the keys are inferred all at once, since a parent always comes before its children
+	keys := InferKeys(fields)
+	fieldsCopy := ds.ShallowCopy(fields)
+	for i := range fieldsCopy {
+		field := &fieldsCopy[i]
+		parentIndex := field.Inferences.ParentIndex
+		if parentIndex == -1 {
+			field.Inferences.HierarchyPath = []string{keys[i]}
+			continue
+		}
+		field.Inferences.HierarchyPath = append(
+			ds.ShallowCopy(fieldsCopy[parentIndex].Inferences.HierarchyPath),
+			keys[i],
+		)
+	}
 	return fieldsCopy
 }
 
+// InferDataTypeByFieldName looks the field name up within the schema in use (see UseSchema).
 func InferDataTypeByFieldName(fieldName string) DataType {
-	switch fieldName {
-	case
-		"requirement_code":
-		return DataTypeChar
-	case
-		"current_hp",
-		"m_Stress":
-		return DataTypeFloat
-	case
-		"read_page_indexes",
-		"raid_read_page_indexes",
-		"raid_unread_page_indexes",
-		"dungeons_unlocked",
-		"played_video_list",
-		"trinket_retention_ids",
-		"last_party_guids",
-		"dungeon_history",
-		"buff_group_guids",
-		"result_event_history",
-		"dead_hero_entries",
-		"additional_mash_disabled_infestation_monster_class_ids",
-		"skill_cooldown_keys",
-		"skill_cooldown_values",
-		"bufferedSpawningSlotsAvailable",
-		"raid_finish_quirk_monster_class_ids",
-		"narration_audio_event_queue_tags",
-		"dispatched_events":
-		return DataTypeIntVector
-	case
-		"goal_ids",
-		"quirk_groups",
-		"backgroundNames":
-		return DataTypeStringVector
-	case
-		"killRange":
-		return DataTypeTwoInt
-	default:
-		return DataTypeUnknown
-	}
-}
-
-func matchOneOfSlices(toBeMatched []any, toMatchSlices [][]any) bool {
-	// `match.OneOf` does not work with slice, hence the workaround
-	// Also see: https://github.com/alexpantyukhin/go-pattern-match/issues/40
-	matcher := match.Match(toBeMatched)
-	for _, toMatchSlice := range toMatchSlices {
-		matcher.When(toMatchSlice, true)
-	}
-	matched, _ := matcher.Result()
-	return matched
+	return schema.DataTypeByFieldName(fieldName)
 }
 
+// InferDataTypeByHierarchyPath looks the hierarchy path (without the root object) up within the schema in use.
 func InferDataTypeByHierarchyPath(hierarchyPath []string) DataType {
-	hierarchyPathAny := make([]any, 0, len(hierarchyPath))
-	for _, item := range hierarchyPath {
-		hierarchyPathAny = append(hierarchyPathAny, item)
-	}
-	switch true {
-	case matchOneOfSlices(
-		hierarchyPathAny,
-		[][]any{
-			{"actor", "buff_group", match.ANY, "amount"},
-			{"chapters", match.ANY, match.ANY, "percent"},
-			{"non_rolled_additional_chances", match.ANY, "chance"},
-		},
-	):
-		return DataTypeFloat
-	case matchOneOfSlices(
-		hierarchyPathAny,
-		[][]any{
-			{"mash", "valid_additional_mash_entry_indexes"},
-			{"party", "heroes"},
-			{"curioGroups", match.ANY, "curios"},
-			{"curioGroups", match.ANY, "curio_table_entries"},
-			{"backer_heroes", match.ANY, "combat_skills"},
-			{"backer_heroes", match.ANY, "camping_skills"},
-			{"backer_heroes", match.ANY, "quirks"},
-		},
-	):
-		return DataTypeIntVector
-	case matchOneOfSlices(
-		hierarchyPathAny,
-		[][]any{
-			{"roaming_dungeon_2_ids", match.ANY, "s"},
-			{"backgroundGroups", match.ANY, "backgrounds"},
-			{"backgroundGroups", match.ANY, "background_table_entries"},
-		},
-	):
-		return DataTypeStringVector
-	case matchOneOfSlices(
-		hierarchyPathAny,
-		[][]any{
-			{"map", "bounds"},
-			{"areas", match.ANY, "bounds"},
-			{"areas", match.ANY, "tiles", match.ANY, "mappos"},
-			{"areas", match.ANY, "tiles", match.ANY, "sidepos"},
-		},
-	):
-		return DataTypeFloatVector
-	}
-	return DataTypeUnknown
+	return schema.DataTypeByHierarchyPath(hierarchyPath)
 }
 
 func InferDataTypeByRawData(rawDataStripped []byte) DataType {
@@ -527,7 +441,7 @@ func InferData(dataType DataType, rawDataStripped []byte) (any, error) {
 }
 
 func AttemptUnhashInt(field DataField) DataField {
-	if field.Inferences.DataType != DataTypeInt {
+	if field.Inferences.DataType != DataTypeInt || !ShouldUnhash(field) {
 		return field
 	}
 	value := field.Inferences.Data.(int32)
@@ -540,7 +454,7 @@ func AttemptUnhashInt(field DataField) DataField {
 }
 
 func AttemptUnhashIntVector(field DataField) DataField {
-	if field.Inferences.DataType != DataTypeIntVector {
+	if field.Inferences.DataType != DataTypeIntVector || !ShouldUnhash(field) {
 		return field
 	}
 	hashedValues := field.Inferences.Data.([]int32)
@@ -567,3 +481,40 @@ func AttemptUnhashIntVector(field DataField) DataField {
 		return field
 	}
 }
+
+// UnhashedValues returns the hashes that the names of the field were found from, or nil if the field does not hold
+// unhashed names.
+func UnhashedValues(field DataField) []int32 {
+	storedDataType := InferDataType(field)
+	switch field.Inferences.DataType {
+	case DataTypeString:
+		if storedDataType != DataTypeInt {
+			return nil
+		}
+		value, err := InferDataInt(field.Inferences.RawDataStripped)
+		if err != nil {
+			return nil
+		}
+		return []int32{value}
+	case DataTypeStringVector, DataTypeHybridVector:
+		if storedDataType != DataTypeIntVector {
+			return nil
+		}
+		values, err := InferDataIntVector(field.Inferences.RawDataStripped)
+		if err != nil {
+			return nil
+		}
+		return values
+	}
+	return nil
+}
+
+// AmbiguousNames returns every name that the unhashed values of the field could be, if any of the hashes is shared by
+// more than one known name (see dhash.CollisionsByHash).
+func AmbiguousNames(field DataField) []string {
+	names := make([]string, 0)
+	for _, value := range UnhashedValues(field) {
+		names = append(names, dhash.CollisionsByHash[value]...)
+	}
+	return lo.Uniq(names)
+}
@@ -38,3 +38,36 @@ func TestInferHierarchyPath(t *testing.T) {
 		assert.Equal(t, expected, InferHierarchyPath(input, fields))
 	}
 }
+
+func TestInferHierarchyPaths_Duplicated(t *testing.T) {
+	createField := func(name string, parentIndex int) DataField {
+		return DataField{
+			Name: name,
+			Inferences: Inferences{
+				ParentIndex: parentIndex,
+			},
+		}
+	}
+	fields := []DataField{
+		createField("root", -1),
+		createField("a", 0),
+		createField("b", 1),
+		createField("a", 0),
+		createField("b", 3),
+		createField("b", 3),
+	}
+	expected := [][]string{
+		{"root"},
+		{"root", "a"},
+		{"root", "a", "b"},
+		{"root", "a__duplicated_dont_touch_2"},
+		{"root", "a__duplicated_dont_touch_2", "b"},
+		{"root", "a__duplicated_dont_touch_2", "b__duplicated_dont_touch_2"},
+	}
+	for i, field := range InferHierarchyPaths(fields) {
+		assert.Equal(t, expected[i], field.Inferences.HierarchyPath)
+		assert.Equal(t, expected[i], InferHierarchyPath(i, fields))
+		assert.Equal(t, field.Name, FromKey(expected[i][len(expected[i])-1]))
+	}
+	assert.Equal(t, "a__duplicated_dont_touch_x", FromKey("a__duplicated_dont_touch_x"))
+}
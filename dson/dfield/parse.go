@@ -0,0 +1,136 @@
+package dfield
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ParseValue reads a value that is typed by users (on the command line, for example) as the given data type.
+// Vectors are written as JSON arrays. The result has the same shape as what `json.Unmarshal` produces, which is what
+// the `EncodeValue` functions expect, except that an int can also be a hashed name like `###crimson_curse`.
+func ParseValue(dataType DataType, text string) (any, error) {
+	errInvalid := ErrInvalidValue{
+		Caller:   "ParseValue",
+		DataType: dataType,
+		Value:    text,
+	}
+	switch dataType {
+	case DataTypeBool:
+		value, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, errInvalid
+		}
+		return value, nil
+	case DataTypeChar:
+		if len(text) != 1 {
+			return nil, errInvalid
+		}
+		return text, nil
+	case DataTypeInt:
+		if strings.HasPrefix(text, "###") {
+			return text, nil
+		}
+		value, err := strconv.ParseInt(text, 10, 32)
+		if err != nil {
+			return nil, errInvalid
+		}
+		return int32(value), nil
+	case DataTypeFloat:
+		value, err := strconv.ParseFloat(text, 32)
+		if err != nil {
+			return nil, errInvalid
+		}
+		return value, nil
+	case DataTypeString:
+		return text, nil
+	case DataTypeIntVector, DataTypeHybridVector:
+		values := make([]any, 0)
+		if err := json.Unmarshal([]byte(text), &values); err != nil {
+			return nil, errInvalid
+		}
+		for _, value := range values {
+			switch value := value.(type) {
+			case float64:
+			case string:
+				if !strings.HasPrefix(value, "###") {
+					return nil, errInvalid
+				}
+			default:
+				return nil, errInvalid
+			}
+		}
+		return values, nil
+	case DataTypeFloatVector:
+		values := make([]float64, 0)
+		if err := json.Unmarshal([]byte(text), &values); err != nil {
+			return nil, errInvalid
+		}
+		return values, nil
+	case DataTypeStringVector:
+		values := make([]string, 0)
+		if err := json.Unmarshal([]byte(text), &values); err != nil {
+			return nil, errInvalid
+		}
+		return values, nil
+	case DataTypeTwoInt:
+		values := make([]float64, 0)
+		if err := json.Unmarshal([]byte(text), &values); err != nil || len(values) != 2 {
+			return nil, errInvalid
+		}
+		return values, nil
+	case DataTypeTwoBool:
+		values := make([]bool, 0)
+		if err := json.Unmarshal([]byte(text), &values); err != nil || len(values) != 2 {
+			return nil, errInvalid
+		}
+		return values, nil
+	}
+	return nil, errInvalid
+}
+
+// SetDataByText replaces the data of the field with the parsed text. The field keeps the data type that it was
+// decoded with, instead of having it implied again from the value: a float stays a float even if the new value is
+// integral, and an int that was shown as a hashed name stays an int.
+func SetDataByText(field DataField, text string) (DataField, error) {
+	// the data type before unhashing is the one that decides how the data is stored
+	dataType := InferDataType(field)
+	value, err := ParseValue(dataType, text)
+	if err != nil {
+		err := errors.Wrapf(err, `dfield.SetDataByText error parsing value of field "%s"`, field.Name)
+		return field, err
+	}
+
+	rawDataStripped := []byte(nil)
+	switch dataType {
+	case DataTypeInt:
+		if valueStr, ok := value.(string); ok {
+			// hashed names are encoded as int by this function
+			rawDataStripped = EncodeValueString(valueStr)
+		} else {
+			rawDataStripped = EncodeValueInt(value)
+		}
+	case DataTypeIntVector, DataTypeHybridVector:
+		rawDataStripped = EncodeValueHybridVector(value)
+	default:
+		rawDataStripped, err = EncodeValue(field.Name, dataType, value)
+		if err != nil {
+			err := errors.Wrapf(err, `dfield.SetDataByText error encoding value of field "%s"`, field.Name)
+			return field, err
+		}
+	}
+
+	data, err := InferData(dataType, rawDataStripped)
+	if err != nil {
+		err := errors.Wrapf(err, `dfield.SetDataByText error inferring value of field "%s"`, field.Name)
+		return field, err
+	}
+	field.Inferences.RawDataStripped = rawDataStripped
+	field.Inferences.DataType = dataType
+	field.Inferences.Data = data
+	field = AttemptUnhashInt(field)
+	field = AttemptUnhashIntVector(field)
+	return field, nil
+}
@@ -0,0 +1,45 @@
+package dfield
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseValue(t *testing.T) {
+	type testCase struct {
+		DataType DataType
+		Text     string
+		Expected any
+	}
+	testCases := []testCase{
+		{DataTypeBool, "true", true},
+		{DataTypeChar, "a", "a"},
+		{DataTypeInt, "-5", int32(-5)},
+		{DataTypeInt, "###crimson_curse", "###crimson_curse"},
+		{DataTypeFloat, "37", float64(37)},
+		{DataTypeString, "Botin", "Botin"},
+		{DataTypeIntVector, `[1, "###crimson_curse"]`, []any{float64(1), "###crimson_curse"}},
+		{DataTypeFloatVector, `[1.5]`, []float64{1.5}},
+		{DataTypeTwoBool, `[true, false]`, []bool{true, false}},
+	}
+	for _, c := range testCases {
+		value, err := ParseValue(c.DataType, c.Text)
+		require.NoError(t, err, c)
+		require.Equal(t, c.Expected, value, c)
+	}
+
+	invalidCases := []testCase{
+		{DataTypeBool, "yes", nil},
+		{DataTypeChar, "ab", nil},
+		{DataTypeInt, "1.5", nil},
+		{DataTypeInt, "4294967295", nil},
+		{DataTypeIntVector, `["plain"]`, nil},
+		{DataTypeTwoInt, `[1]`, nil},
+		{DataTypeObject, `{}`, nil},
+	}
+	for _, c := range invalidCases {
+		_, err := ParseValue(c.DataType, c.Text)
+		require.Error(t, err, c)
+	}
+}
@@ -0,0 +1,178 @@
+package dfield
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+)
+
+type (
+	// Schema holds the data types that cannot be guessed from the raw data (or the JSON value) of a field. A field is
+	// looked up by its name first, and then by its hierarchy path (without the root object).
+	//
+	// It also holds the fields whose integers are hashed names, which are looked up the same way (see UnhashModeSafe).
+	Schema struct {
+		FieldNames           map[string]DataType `json:"field_names"`
+		HierarchyPaths       []HierarchyPathRule `json:"hierarchy_paths"`
+		UnhashFieldNames     []string            `json:"unhash_field_names"`
+		UnhashHierarchyPaths [][]string          `json:"unhash_hierarchy_paths"`
+	}
+	// HierarchyPathRule gives the data type of the fields whose hierarchy paths match Path, where SchemaWildcard
+	// matches any key.
+	HierarchyPathRule struct {
+		Path     []string `json:"path"`
+		DataType DataType `json:"type"`
+	}
+
+	ErrInvalidSchema struct {
+		Caller string
+		Reason string
+	}
+)
+
+// SchemaWildcard matches any key within the path of a HierarchyPathRule.
+const SchemaWildcard = "*"
+
+//go:embed schema.json
+var defaultSchemaBytes []byte
+
+// schema is the registry that is used by InferDataType and ImplyDataType.
+var schema Schema
+
+func init() {
+	defaultSchema, err := ReadSchema(defaultSchemaBytes)
+	if err != nil {
+		panic(err)
+	}
+	schema = *defaultSchema
+}
+
+func (r ErrInvalidSchema) Error() string {
+	msg := fmt.Sprintf(
+		`%s: invalid schema: %s`,
+		r.Caller, r.Reason,
+	)
+	return msg
+}
+
+// ReadSchema parses and validates a schema file, whose layout is the same as the embedded `schema.json`.
+func ReadSchema(bs []byte) (*Schema, error) {
+	newSchema := Schema{}
+	if err := json.Unmarshal(bs, &newSchema); err != nil {
+		err := errors.Wrap(err, "dfield.ReadSchema error")
+		return nil, err
+	}
+	for fieldName, dataType := range newSchema.FieldNames {
+		if !IsSchemaDataType(dataType) {
+			return nil, ErrInvalidSchema{
+				Caller: "ReadSchema",
+				Reason: fmt.Sprintf(`unsupported data type "%s" of field name "%s"`, dataType, fieldName),
+			}
+		}
+	}
+	for i, rule := range newSchema.HierarchyPaths {
+		if len(rule.Path) == 0 {
+			return nil, ErrInvalidSchema{
+				Caller: "ReadSchema",
+				Reason: fmt.Sprintf(`empty path of hierarchy path rule #%d`, i),
+			}
+		}
+		if !IsSchemaDataType(rule.DataType) {
+			return nil, ErrInvalidSchema{
+				Caller: "ReadSchema",
+				Reason: fmt.Sprintf(`unsupported data type "%s" of hierarchy path rule #%d`, rule.DataType, i),
+			}
+		}
+	}
+	for i, path := range newSchema.UnhashHierarchyPaths {
+		if len(path) == 0 {
+			return nil, ErrInvalidSchema{
+				Caller: "ReadSchema",
+				Reason: fmt.Sprintf(`empty path of unhash hierarchy path #%d`, i),
+			}
+		}
+	}
+	return &newSchema, nil
+}
+
+// IsSchemaDataType tells whether the data type can be used within a schema, which excludes the data types of
+// objects and embedded files.
+func IsSchemaDataType(dataType DataType) bool {
+	return lo.Contains(
+		[]DataType{
+			DataTypeBool,
+			DataTypeChar,
+			DataTypeInt,
+			DataTypeFloat,
+			DataTypeString,
+			DataTypeIntVector,
+			DataTypeFloatVector,
+			DataTypeStringVector,
+			DataTypeHybridVector,
+			DataTypeTwoBool,
+			DataTypeTwoInt,
+		},
+		dataType,
+	)
+}
+
+// DefaultSchema returns the schema that is embedded within the binary.
+func DefaultSchema() Schema {
+	defaultSchema, _ := ReadSchema(defaultSchemaBytes)
+	return *defaultSchema
+}
+
+// CurrentSchema returns the schema that is in use.
+func CurrentSchema() Schema {
+	return schema
+}
+
+// UseSchema replaces the schema that is in use, which is the embedded one by default.
+func UseSchema(newSchema Schema) {
+	schema = newSchema
+}
+
+func (s Schema) DataTypeByFieldName(fieldName string) DataType {
+	if dataType, ok := s.FieldNames[FromKey(fieldName)]; ok {
+		return dataType
+	}
+	return DataTypeUnknown
+}
+
+func (s Schema) DataTypeByHierarchyPath(hierarchyPath []string) DataType {
+	for _, rule := range s.HierarchyPaths {
+		if MatchSchemaPath(rule.Path, hierarchyPath) {
+			return rule.DataType
+		}
+	}
+	return DataTypeUnknown
+}
+
+// Unhashes tells whether the integers of the field are hashed names, by its name or by its hierarchy path (without the
+// root object).
+func (s Schema) Unhashes(fieldName string, hierarchyPath []string) bool {
+	if lo.Contains(s.UnhashFieldNames, FromKey(fieldName)) {
+		return true
+	}
+	return lo.SomeBy(
+		s.UnhashHierarchyPaths,
+		func(pattern []string) bool { return MatchSchemaPath(pattern, hierarchyPath) },
+	)
+}
+
+// MatchSchemaPath tells whether the hierarchy path matches the pattern of a HierarchyPathRule. The keys of duplicated
+// fields (see ToKey) are matched by their field names.
+func MatchSchemaPath(pattern []string, hierarchyPath []string) bool {
+	if len(pattern) != len(hierarchyPath) {
+		return false
+	}
+	for i, key := range hierarchyPath {
+		if pattern[i] != SchemaWildcard && pattern[i] != FromKey(key) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package dfield
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSchema(t *testing.T) {
+	assert.Equal(t, DataTypeFloat, InferDataTypeByFieldName("current_hp"))
+	assert.Equal(t, DataTypeTwoInt, InferDataTypeByFieldName("killRange"))
+	assert.Equal(t, DataTypeUnknown, InferDataTypeByFieldName("roster.status"))
+	assert.Equal(t, DataTypeFloatVector, InferDataTypeByHierarchyPath([]string{"areas", "1", "tiles", "2", "mappos"}))
+	assert.Equal(t, DataTypeFloat, InferDataTypeByHierarchyPath([]string{"chapters", "1", "2", "percent"}))
+	assert.Equal(t, DataTypeUnknown, InferDataTypeByHierarchyPath([]string{"chapters", "1", "percent"}))
+	assert.Equal(
+		t,
+		DataTypeIntVector,
+		InferDataTypeByHierarchyPath([]string{"party", "heroes" + FieldNameDuplicationSuffix + "2"}),
+	)
+}
+
+func TestUseSchema(t *testing.T) {
+	defer UseSchema(DefaultSchema())
+
+	schema, err := ReadSchema([]byte(`{
+  "field_names": {"gold": "float"},
+  "hierarchy_paths": [{"path": ["wallet", "*", "amount"], "type": "float"}]
+}`))
+	require.NoError(t, err)
+	UseSchema(*schema)
+
+	assert.Equal(t, DataTypeFloat, ImplyDataType("gold", []string{"gold"}, float64(1)))
+	assert.Equal(t, DataTypeFloat, ImplyDataType("amount", []string{"wallet", "0", "amount"}, float64(1)))
+	assert.Equal(t, DataTypeInt, ImplyDataType("current_hp", []string{"actor", "current_hp"}, float64(1)))
+}
+
+func TestReadSchema_Invalid(t *testing.T) {
+	_, err := ReadSchema([]byte(`{"field_names": {"gold": "object"}}`))
+	assert.ErrorAs(t, err, &ErrInvalidSchema{})
+	_, err = ReadSchema([]byte(`{"hierarchy_paths": [{"path": [], "type": "int"}]}`))
+	assert.ErrorAs(t, err, &ErrInvalidSchema{})
+	_, err = ReadSchema([]byte(`{"unhash_hierarchy_paths": [[]]}`))
+	assert.ErrorAs(t, err, &ErrInvalidSchema{})
+	_, err = ReadSchema([]byte(`{"field_names": []}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package dfield
+
+import (
+	"github.com/samber/lo"
+)
+
+type (
+	// UnhashMode decides which integers are turned into names (see AttemptUnhashInt and AttemptUnhashIntVector).
+	UnhashMode string
+)
+
+const (
+	// UnhashModeOff keeps every integer as is.
+	UnhashModeOff = UnhashMode("off")
+	// UnhashModeSafe only unhashes the fields that are known to hold names by the schema, like the IDs of classes,
+	// quests, or dungeons.
+	UnhashModeSafe = UnhashMode("safe")
+	// UnhashModeAggressive unhashes every integer that matches a known hash, which can turn an ordinary number into a
+	// name by chance.
+	UnhashModeAggressive = UnhashMode("aggressive")
+)
+
+// UnhashModes lists the supported modes.
+var UnhashModes = []UnhashMode{UnhashModeOff, UnhashModeSafe, UnhashModeAggressive}
+
+// unhashMode is the mode that is used while decoding.
+var unhashMode = UnhashModeSafe
+
+// CurrentUnhashMode returns the mode that is in use.
+func CurrentUnhashMode() UnhashMode {
+	return unhashMode
+}
+
+// UseUnhashMode replaces the mode that is in use, which is UnhashModeSafe by default.
+func UseUnhashMode(mode UnhashMode) {
+	unhashMode = mode
+}
+
+// ShouldUnhash tells whether the integers of the field can be turned into names with the mode that is in use.
+func ShouldUnhash(field DataField) bool {
+	switch unhashMode {
+	case UnhashModeAggressive:
+		return true
+	case UnhashModeSafe:
+		hierarchyPathWithoutRoot := lo.Drop(field.Inferences.HierarchyPath, 1)
+		return schema.Unhashes(field.Name, hierarchyPathWithoutRoot)
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package dfield
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dhash"
+)
+
+func TestAttemptUnhashInt_Modes(t *testing.T) {
+	defer UseUnhashMode(CurrentUnhashMode())
+
+	createField := func(hierarchyPath []string) DataField {
+		return DataField{
+			Name: hierarchyPath[len(hierarchyPath)-1],
+			Inferences: Inferences{
+				HierarchyPath: hierarchyPath,
+				DataType:      DataTypeInt,
+				Data:          dhash.HashString("crusader"),
+			},
+		}
+	}
+	classField := createField([]string{"base_root", "chapters", "1", "2", "class"})
+	goldField := createField([]string{"base_root", "wallet", "0", "amount"})
+
+	UseUnhashMode(UnhashModeSafe)
+	assert.Equal(t, "###crusader", AttemptUnhashInt(classField).Inferences.Data)
+	assert.Equal(t, dhash.HashString("crusader"), AttemptUnhashInt(goldField).Inferences.Data)
+
+	UseUnhashMode(UnhashModeAggressive)
+	assert.Equal(t, "###crusader", AttemptUnhashInt(classField).Inferences.Data)
+	assert.Equal(t, "###crusader", AttemptUnhashInt(goldField).Inferences.Data)
+
+	UseUnhashMode(UnhashModeOff)
+	assert.Equal(t, DataTypeInt, AttemptUnhashInt(classField).Inferences.DataType)
+	assert.Equal(t, DataTypeInt, AttemptUnhashInt(goldField).Inferences.DataType)
+}
+
+func TestSchema_Unhashes(t *testing.T) {
+	schema := DefaultSchema()
+	assert.True(t, schema.Unhashes("dungeon_history", []string{"dungeon_history"}))
+	assert.True(t, schema.Unhashes("class", []string{"chapters", "1", "2", "heroes", "0", "class"}))
+	assert.False(t, schema.Unhashes("class", []string{"class"}))
+	assert.False(t, schema.Unhashes("amount", []string{"wallet", "0", "amount"}))
+}
@@ -10,21 +10,66 @@ import (
 //go:embed names.txt
 var names string
 
+// NameByHash maps the hashes of the known names to the names with prefix "###".
 var NameByHash map[int32]string
 
+// CollisionsByHash maps the hashes that more than one known name hashes to, to all of the names with prefix "###".
+// The first one is the name within NameByHash.
+var CollisionsByHash map[int32][]string
+
+// knownNames are the names of NameByHash in the order they were added.
+var knownNames []string
+
 func init() {
-	namesSlice := strings.Split(names, "\n")
-	namesSlice = lo.Filter(
-		namesSlice,
-		func(line string, _ int) bool {
-			return len(strings.TrimSpace(line)) > 0
+	NameByHash = make(map[int32]string)
+	CollisionsByHash = make(map[int32][]string)
+	knownNames = make([]string, 0)
+	AddNames(ParseNames(names))
+}
+
+// ParseNames reads a dictionary of names, one per line. Blank lines and comments (lines that start with "//") are
+// skipped, and prefix "###" is optional.
+func ParseNames(text string) []string {
+	lines := lo.Map(
+		strings.Split(text, "\n"),
+		func(line string, _ int) string {
+			return strings.TrimPrefix(strings.TrimSpace(line), "###")
 		},
 	)
-
-	NameByHash = lo.SliceToMap[string, int32, string](
-		namesSlice,
-		func(name string) (int32, string) {
-			return HashString(name), "###" + name
+	return lo.Filter(
+		lines,
+		func(line string, _ int) bool {
+			return len(line) > 0 && !strings.HasPrefix(line, "//")
 		},
 	)
 }
+
+// AddNames merges the names into NameByHash, and returns the number of hashes that were not known before. A known
+// hash keeps its name, and the other names of the hash are recorded within CollisionsByHash.
+func AddNames(names []string) int {
+	addedCount := 0
+	for _, name := range names {
+		hash := HashString(name)
+		if knownName, ok := NameByHash[hash]; ok {
+			prefixedName := "###" + name
+			collidedNames := CollisionsByHash[hash]
+			if prefixedName != knownName && !lo.Contains(collidedNames, prefixedName) {
+				if len(collidedNames) == 0 {
+					collidedNames = []string{knownName}
+				}
+				CollisionsByHash[hash] = append(collidedNames, prefixedName)
+			}
+			continue
+		}
+		NameByHash[hash] = "###" + name
+		knownNames = append(knownNames, name)
+		addedCount += 1
+	}
+	return addedCount
+}
+
+// KnownNames returns the names of NameByHash (without prefix "###") in the order they were added, which starts with
+// the embedded ones.
+func KnownNames() []string {
+	return append([]string{}, knownNames...)
+}
@@ -0,0 +1,58 @@
+package dhash
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	quotedStringRegexp = regexp.MustCompile(`"([^"\r\n]*)"`)
+	xmlIDRegexp        = regexp.MustCompile(`\bid\s*=\s*"([^"\r\n]*)"`)
+	identifierRegexp   = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_. +\-]*$`)
+	letterRegexp       = regexp.MustCompile(`[A-Za-z]`)
+)
+
+// maxIdentifierLength is longer than every embedded name, to skip most of the sentences.
+const maxIdentifierLength = 64
+
+// IsHarvestable tells whether the file of the game data can have names: a data file (".darkest", including
+// ".info.darkest"), a JSON file, or a localization file (".xml").
+func IsHarvestable(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".darkest" || ext == ".json" || ext == ".xml"
+}
+
+// IsIdentifier tells whether the text looks like a name that the game hashes, such as "crusader", "sun_ring", or
+// "abomination.slam", rather than a sentence or a number.
+func IsIdentifier(text string) bool {
+	return len(text) <= maxIdentifierLength &&
+		identifierRegexp.MatchString(text) &&
+		letterRegexp.MatchString(text) &&
+		strings.TrimSpace(text) == text
+}
+
+// HarvestNames finds the identifiers within a file of the game data, in the order they first appear. They are the
+// quoted strings of the data and JSON files (like `.id "smite"`), the IDs of the localization entries, and the name
+// of the file itself without its extensions (like "crusader" for "crusader.info.darkest").
+func HarvestNames(path string, content []byte) []string {
+	regex := quotedStringRegexp
+	if strings.EqualFold(filepath.Ext(path), ".xml") {
+		regex = xmlIDRegexp
+	}
+	texts := []string{strings.SplitN(filepath.Base(path), ".", 2)[0]}
+	for _, match := range regex.FindAllSubmatch(content, -1) {
+		texts = append(texts, string(match[1]))
+	}
+
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		if seen[text] || !IsIdentifier(text) {
+			continue
+		}
+		seen[text] = true
+		names = append(names, text)
+	}
+	return names
+}
@@ -0,0 +1,40 @@
+package dhash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsIdentifier(t *testing.T) {
+	for _, name := range ParseNames(names) {
+		assert.True(t, IsIdentifier(name), name)
+	}
+	assert.False(t, IsIdentifier("1.5"))
+	assert.False(t, IsIdentifier("Strikes a single enemy, dealing damage."))
+	assert.False(t, IsIdentifier(" padded "))
+}
+
+func TestHarvestNames(t *testing.T) {
+	darkest := []byte(
+		"combat_skill: .id \"smite\" .level 0 .type \"melee\" .atk 85%\n" +
+			"combat_skill: .id \"smite\" .level 1 .type \"melee\" .effect \"Stun 1\"\n",
+	)
+	assert.Equal(
+		t,
+		[]string{"crusader", "smite", "melee", "Stun 1"},
+		HarvestNames("heroes/crusader/crusader.info.darkest", darkest),
+	)
+
+	xml := []byte(
+		`<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+			`<root><language id="english">` +
+			`<entry id="str_sun_ring"><![CDATA[Sun Ring, "the best one"]]></entry>` +
+			`</language></root>`,
+	)
+	assert.Equal(
+		t,
+		[]string{"trinkets", "english", "str_sun_ring"},
+		HarvestNames("localization/trinkets.string_table.xml", xml),
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHashString(t *testing.T) {
@@ -16,3 +17,35 @@ func TestHashString(t *testing.T) {
 		assert.Equal(t, HashString(s), i)
 	}
 }
+
+func TestParseNames(t *testing.T) {
+	text := "// skills of a mod\r\nmod_skill_1\n\n  ###mod_skill_2  \n"
+	assert.Equal(t, []string{"mod_skill_1", "mod_skill_2"}, ParseNames(text))
+}
+
+func TestAddNames(t *testing.T) {
+	defer func(savedKnownNames []string) {
+		delete(NameByHash, HashString("mod_skill_1"))
+		knownNames = savedKnownNames
+	}(KnownNames())
+
+	assert.Equal(t, 1, AddNames([]string{"crusader", "mod_skill_1"}))
+	assert.Equal(t, "###mod_skill_1", NameByHash[HashString("mod_skill_1")])
+	assert.Equal(t, "###crusader", NameByHash[HashString("crusader")])
+	assert.Equal(t, 0, AddNames([]string{"mod_skill_1"}))
+	assert.Equal(t, "mod_skill_1", KnownNames()[len(KnownNames())-1])
+}
+
+func TestAddNames_Collision(t *testing.T) {
+	hash := HashString("mod_ab")
+	require.Equal(t, hash, HashString("mod_b-"))
+	defer func(savedKnownNames []string) {
+		delete(NameByHash, hash)
+		delete(CollisionsByHash, hash)
+		knownNames = savedKnownNames
+	}(KnownNames())
+
+	assert.Equal(t, 1, AddNames([]string{"mod_ab", "mod_b-", "mod_ab", "mod_b-"}))
+	assert.Equal(t, "###mod_ab", NameByHash[hash])
+	assert.Equal(t, []string{"###mod_ab", "###mod_b-"}, CollisionsByHash[hash])
+}
@@ -0,0 +1,165 @@
+package dson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	Difference struct {
+		Kind    DifferenceKind  `json:"kind"`
+		Path    []string        `json:"path"`
+		OldType dfield.DataType `json:"old_type,omitempty"`
+		NewType dfield.DataType `json:"new_type,omitempty"`
+		Old     any             `json:"old,omitempty"`
+		New     any             `json:"new,omitempty"`
+	}
+	DifferenceKind string
+)
+
+const (
+	DifferenceKindAdded   = DifferenceKind("added")
+	DifferenceKindRemoved = DifferenceKind("removed")
+	DifferenceKindChanged = DifferenceKind("changed")
+)
+
+// Diff compares two decoded files field by field. An added or removed object (or embedded file) is reported once,
+// without its children. Containers are not compared themselves, since their changes show up in their children.
+func Diff(oldFile dstruct.Struct, newFile dstruct.Struct) []Difference {
+	oldFlatFields := Flatten(oldFile)
+	newFlatFields := Flatten(newFile)
+	oldKeys, oldIndexes := indexFlatFields(oldFlatFields)
+	newKeys, newIndexes := indexFlatFields(newFlatFields)
+
+	differences := make([]Difference, 0)
+	removedPrefixes := make([]string, 0)
+	for i, oldFlatField := range oldFlatFields {
+		key := oldKeys[i]
+		newIndex, ok := newIndexes[key]
+		if !ok {
+			if hasAnyPrefix(key.Path, removedPrefixes) {
+				continue
+			}
+			if oldFlatField.IsContainer() {
+				removedPrefixes = append(removedPrefixes, key.Path+dstruct.PathSeparator)
+			}
+			differences = append(
+				differences,
+				Difference{
+					Kind:    DifferenceKindRemoved,
+					Path:    oldFlatField.Path,
+					OldType: oldFlatField.Field.Inferences.DataType,
+					Old:     oldFlatField.Field.Inferences.Data,
+				},
+			)
+			continue
+		}
+		newFlatField := newFlatFields[newIndex]
+		if oldFlatField.IsContainer() && newFlatField.IsContainer() {
+			continue
+		}
+		oldInferences := oldFlatField.Field.Inferences
+		newInferences := newFlatField.Field.Inferences
+		if oldInferences.DataType != newInferences.DataType ||
+			!bytes.Equal(oldInferences.RawDataStripped, newInferences.RawDataStripped) {
+			differences = append(
+				differences,
+				Difference{
+					Kind:    DifferenceKindChanged,
+					Path:    oldFlatField.Path,
+					OldType: oldInferences.DataType,
+					NewType: newInferences.DataType,
+					Old:     oldInferences.Data,
+					New:     newInferences.Data,
+				},
+			)
+		}
+	}
+
+	addedPrefixes := make([]string, 0)
+	for i, newFlatField := range newFlatFields {
+		key := newKeys[i]
+		if _, ok := oldIndexes[key]; ok {
+			continue
+		}
+		if hasAnyPrefix(key.Path, addedPrefixes) {
+			continue
+		}
+		if newFlatField.IsContainer() {
+			addedPrefixes = append(addedPrefixes, key.Path+dstruct.PathSeparator)
+		}
+		differences = append(
+			differences,
+			Difference{
+				Kind:    DifferenceKindAdded,
+				Path:    newFlatField.Path,
+				NewType: newFlatField.Field.Inferences.DataType,
+				New:     newFlatField.Field.Inferences.Data,
+			},
+		)
+	}
+
+	return differences
+}
+
+func (r Difference) String() string {
+	path := strings.Join(r.Path, ".")
+	switch r.Kind {
+	case DifferenceKindAdded:
+		return fmt.Sprintf("+ %s (%s): %s", path, r.NewType, FormatValue(r.NewType, r.New))
+	case DifferenceKindRemoved:
+		return fmt.Sprintf("- %s (%s): %s", path, r.OldType, FormatValue(r.OldType, r.Old))
+	}
+	if r.OldType != r.NewType {
+		return fmt.Sprintf(
+			"~ %s: (%s) %s -> (%s) %s",
+			path, r.OldType, FormatValue(r.OldType, r.Old), r.NewType, FormatValue(r.NewType, r.New),
+		)
+	}
+	return fmt.Sprintf(
+		"~ %s (%s): %s -> %s",
+		path, r.OldType, FormatValue(r.OldType, r.Old), FormatValue(r.NewType, r.New),
+	)
+}
+
+// FormatValue renders value in a short form: objects and embedded files are abbreviated, and the rest is JSON.
+func FormatValue(dataType dfield.DataType, value any) string {
+	switch dataType {
+	case dfield.DataTypeObject:
+		return "{...}"
+	case dfield.DataTypeFileDecoded:
+		return "<embedded file>"
+	}
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(valueBytes)
+}
+
+// indexFlatFields returns the key of each flat field (see dstruct.IndexPaths), and the index of each key.
+func indexFlatFields(flatFields []FlatField) ([]dstruct.PathKey, map[dstruct.PathKey]int) {
+	keys := dstruct.IndexPaths(
+		lo.Map(flatFields, func(flatField FlatField, _ int) []string { return flatField.Path }),
+	)
+	indexes := make(map[dstruct.PathKey]int, len(keys))
+	for i, key := range keys {
+		indexes[key] = i
+	}
+	return keys, indexes
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package dson
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// updateNode applies f to the object at keys within node, and returns the updated node. Nested objects are values
+// rather than pointers, so each of them is set back into its parent.
+func updateNode(
+	t *testing.T,
+	node orderedmap.OrderedMap,
+	keys []string,
+	f func(orderedmap.OrderedMap) orderedmap.OrderedMap,
+) orderedmap.OrderedMap {
+	if len(keys) == 0 {
+		return f(node)
+	}
+	value, ok := node.Get(keys[0])
+	require.True(t, ok, `key "%s" not found`, keys[0])
+	child, ok := value.(orderedmap.OrderedMap)
+	require.True(t, ok, `value at key "%s" is not an object`, keys[0])
+	node.Set(keys[0], updateNode(t, child, keys[1:], f))
+	return node
+}
+
+func TestDiff(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.roster.json")
+	require.NoError(t, err)
+	oldFile, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+	require.Empty(t, Diff(*oldFile, *oldFile))
+
+	heroPath := []string{"base_root", "heroes", "56", "hero_file_data", "raw_data", "base_root"}
+	node, err := dstruct.ToJSONNode(*oldFile)
+	require.NoError(t, err)
+	newNode := updateNode(
+		t, *node, heroPath,
+		func(hero orderedmap.OrderedMap) orderedmap.OrderedMap {
+			hero.Set("m_Stress", float64(35))
+			quirks, _ := hero.Get("quirks")
+			quirksNode := quirks.(orderedmap.OrderedMap)
+			quirksNode.Delete("unquiet_mind")
+			hero.Set("quirks", quirksNode)
+			return hero
+		},
+	)
+	newFile, err := dstruct.FromJSONNode(newNode, oldFile)
+	require.NoError(t, err)
+	newFile, err = dstruct.ToStructuredFile(dstruct.EncodeStruct(*newFile))
+	require.NoError(t, err)
+
+	differences := Diff(*oldFile, *newFile)
+	require.Equal(
+		t,
+		[]Difference{
+			{
+				Kind:    DifferenceKindChanged,
+				Path:    append(heroPath, "m_Stress"),
+				OldType: dfield.DataTypeFloat,
+				NewType: dfield.DataTypeFloat,
+				Old:     float32(8),
+				New:     float32(35),
+			},
+			{
+				Kind:    DifferenceKindRemoved,
+				Path:    append(heroPath, "quirks", "unquiet_mind"),
+				OldType: dfield.DataTypeObject,
+				Old:     nil,
+			},
+		},
+		differences,
+	)
+	require.Equal(
+		t,
+		"~ base_root.heroes.56.hero_file_data.raw_data.base_root.m_Stress (float): 8 -> 35",
+		differences[0].String(),
+	)
+}
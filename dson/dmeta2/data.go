@@ -36,3 +36,8 @@ type (
 const (
 	DefaultEntrySize = 12
 )
+
+const (
+	// FieldInfoUnknownBits masks the highest bit of `FieldInfo`, which seems to have no meaning.
+	FieldInfoUnknownBits = int32(-1 << 31)
+)
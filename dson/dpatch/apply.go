@@ -0,0 +1,291 @@
+package dpatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+)
+
+// DecodeOperations reads a JSON Patch document, which is an array of operations.
+func DecodeOperations(bs []byte) ([]Operation, error) {
+	operations := make([]Operation, 0)
+	if err := json.Unmarshal(bs, &operations); err != nil {
+		err := errors.Wrap(err, "dpatch.DecodeOperations error")
+		return nil, err
+	}
+	return operations, nil
+}
+
+// Apply applies the operations in order and returns the patched document. Either every operation succeeds, or the
+// input document is returned untouched together with the error, since the document is never modified in place.
+func Apply(document orderedmap.OrderedMap, operations []Operation) (orderedmap.OrderedMap, error) {
+	result := any(document)
+	for index, operation := range operations {
+		patched, err := ApplyOperation(result, operation)
+		if err != nil {
+			err := errors.Wrapf(err, `dpatch.Apply error at operation "%d"`, index)
+			return document, err
+		}
+		result = patched
+	}
+	resultLHM, ok := result.(orderedmap.OrderedMap)
+	if !ok {
+		return document, ErrInvalidOperation{
+			Caller: "Apply",
+			Reason: "the patched document is not an object",
+		}
+	}
+	return resultLHM, nil
+}
+
+func ApplyOperation(document any, operation Operation) (any, error) {
+	tokens, err := ParsePointer(operation.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch operation.Op {
+	case OperationTypeAdd, OperationTypeReplace, OperationTypeTest:
+		if len(operation.Value) == 0 {
+			return nil, ErrInvalidOperation{
+				Caller: "ApplyOperation",
+				Reason: fmt.Sprintf(`operation "%s" needs "value"`, operation.Op),
+			}
+		}
+		value, err := DecodeValue(operation.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch operation.Op {
+		case OperationTypeAdd:
+			return add(document, tokens, operation.Path, value)
+		case OperationTypeReplace:
+			return replace(document, tokens, operation.Path, value)
+		default:
+			return document, test(document, tokens, operation.Path, value)
+		}
+	case OperationTypeRemove:
+		return remove(document, tokens, operation.Path)
+	case OperationTypeMove, OperationTypeCopy:
+		if operation.From == "" {
+			return nil, ErrInvalidOperation{
+				Caller: "ApplyOperation",
+				Reason: fmt.Sprintf(`operation "%s" needs "from"`, operation.Op),
+			}
+		}
+		fromTokens, err := ParsePointer(operation.From)
+		if err != nil {
+			return nil, err
+		}
+		value, ok := Get(document, fromTokens)
+		if !ok {
+			return nil, ErrPathNotFound{
+				Caller: "ApplyOperation",
+				Path:   operation.From,
+			}
+		}
+		if operation.Op == OperationTypeCopy {
+			return add(document, tokens, operation.Path, value)
+		}
+		if strings.HasPrefix(operation.Path, operation.From+"/") {
+			return nil, ErrInvalidOperation{
+				Caller: "ApplyOperation",
+				Reason: fmt.Sprintf(`cannot move "%s" into its own child "%s"`, operation.From, operation.Path),
+			}
+		}
+		document, err = remove(document, fromTokens, operation.From)
+		if err != nil {
+			return nil, err
+		}
+		return add(document, tokens, operation.Path, value)
+	}
+
+	return nil, ErrInvalidOperation{
+		Caller: "ApplyOperation",
+		Reason: fmt.Sprintf(`unknown operation "%s"`, operation.Op),
+	}
+}
+
+// DecodeValue reads a JSON value into the same shape as the document, which means objects become
+// `orderedmap.OrderedMap` instead of `map[string]any`.
+func DecodeValue(raw json.RawMessage) (any, error) {
+	wrapperBytes := append(append([]byte(`{"value":`), raw...), '}')
+	wrapper := orderedmap.New()
+	if err := json.Unmarshal(wrapperBytes, wrapper); err != nil {
+		err := errors.Wrap(err, "dpatch.DecodeValue error")
+		return nil, err
+	}
+	value, _ := wrapper.Get("value")
+	return value, nil
+}
+
+// update replaces the container that holds the value at tokens with the result of f, and returns the updated
+// document. Every container on the way is copied instead of being modified in place.
+func update(
+	document any,
+	tokens []string,
+	path string,
+	f func(container any, token string) (any, error),
+) (any, error) {
+	if len(tokens) == 1 {
+		return f(document, tokens[0])
+	}
+	child, ok := getChild(document, tokens[0])
+	if !ok {
+		return nil, ErrPathNotFound{
+			Caller: "update",
+			Path:   path,
+		}
+	}
+	updatedChild, err := update(child, tokens[1:], path, f)
+	if err != nil {
+		return nil, err
+	}
+	switch container := document.(type) {
+	case orderedmap.OrderedMap:
+		result := copyObject(container)
+		result.Set(tokens[0], updatedChild)
+		return *result, nil
+	case []any:
+		// the index is valid, since the child was found
+		index, _ := parseArrayIndex(tokens[0], len(container), false)
+		result := append([]any{}, container...)
+		result[index] = updatedChild
+		return result, nil
+	}
+	return nil, ErrPathNotFound{
+		Caller: "update",
+		Path:   path,
+	}
+}
+
+func add(document any, tokens []string, path string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return update(
+		document, tokens, path,
+		func(container any, token string) (any, error) {
+			switch container := container.(type) {
+			case orderedmap.OrderedMap:
+				result := copyObject(container)
+				result.Set(token, value)
+				return *result, nil
+			case []any:
+				index, ok := parseArrayIndex(token, len(container), true)
+				if !ok {
+					break
+				}
+				result := make([]any, 0, len(container)+1)
+				result = append(result, container[:index]...)
+				result = append(result, value)
+				result = append(result, container[index:]...)
+				return result, nil
+			}
+			return nil, ErrPathNotFound{
+				Caller: "add",
+				Path:   path,
+			}
+		},
+	)
+}
+
+func remove(document any, tokens []string, path string) (any, error) {
+	if len(tokens) == 0 {
+		return nil, ErrInvalidOperation{
+			Caller: "remove",
+			Reason: "cannot remove the whole document",
+		}
+	}
+	return update(
+		document, tokens, path,
+		func(container any, token string) (any, error) {
+			if _, ok := getChild(container, token); !ok {
+				return nil, ErrPathNotFound{
+					Caller: "remove",
+					Path:   path,
+				}
+			}
+			switch container := container.(type) {
+			case orderedmap.OrderedMap:
+				result := copyObject(container)
+				result.Delete(token)
+				return *result, nil
+			case []any:
+				index, _ := parseArrayIndex(token, len(container), false)
+				result := make([]any, 0, len(container)-1)
+				result = append(result, container[:index]...)
+				result = append(result, container[index+1:]...)
+				return result, nil
+			}
+			return nil, ErrPathNotFound{
+				Caller: "remove",
+				Path:   path,
+			}
+		},
+	)
+}
+
+func replace(document any, tokens []string, path string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	return update(
+		document, tokens, path,
+		func(container any, token string) (any, error) {
+			if _, ok := getChild(container, token); !ok {
+				return nil, ErrPathNotFound{
+					Caller: "replace",
+					Path:   path,
+				}
+			}
+			switch container := container.(type) {
+			case orderedmap.OrderedMap:
+				result := copyObject(container)
+				result.Set(token, value)
+				return *result, nil
+			case []any:
+				index, _ := parseArrayIndex(token, len(container), false)
+				result := append([]any{}, container...)
+				result[index] = value
+				return result, nil
+			}
+			return nil, ErrPathNotFound{
+				Caller: "replace",
+				Path:   path,
+			}
+		},
+	)
+}
+
+func test(document any, tokens []string, path string, value any) error {
+	actual, ok := Get(document, tokens)
+	if !ok {
+		return ErrPathNotFound{
+			Caller: "test",
+			Path:   path,
+		}
+	}
+	// comparing the JSON forms means 1 and 1.0 are equal, which is what RFC 6902 wants
+	actualBytes, err := json.Marshal(actual)
+	if err != nil {
+		return errors.Wrap(err, "dpatch.test error marshalling actual value")
+	}
+	expectedBytes, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "dpatch.test error marshalling expected value")
+	}
+	if !bytes.Equal(actualBytes, expectedBytes) {
+		return ErrTestFailed{
+			Caller:   "test",
+			Path:     path,
+			Expected: string(expectedBytes),
+			Actual:   string(actualBytes),
+		}
+	}
+	return nil
+}
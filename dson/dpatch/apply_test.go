@@ -0,0 +1,80 @@
+package dpatch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const document = `{"base_root":{"wallet":{"0":{"amount":100,"type":"gold"}},"ids":[1,2,3],"a~b/c":true}}`
+
+func TestApply(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Patch    string
+		Expected string
+	}
+	testCases := []testCase{
+		{
+			Name:     "replace",
+			Patch:    `[{"op":"replace","path":"/base_root/wallet/0/amount","value":500}]`,
+			Expected: `{"base_root":{"wallet":{"0":{"amount":500,"type":"gold"}},"ids":[1,2,3],"a~b/c":true}}`,
+		},
+		{
+			Name:     "add to object and array",
+			Patch:    `[{"op":"add","path":"/base_root/x","value":{"b":1,"a":2}},{"op":"add","path":"/base_root/ids/-","value":4},{"op":"add","path":"/base_root/ids/0","value":0}]`,
+			Expected: `{"base_root":{"wallet":{"0":{"amount":100,"type":"gold"}},"ids":[0,1,2,3,4],"a~b/c":true,"x":{"b":1,"a":2}}}`,
+		},
+		{
+			Name:     "remove escaped key",
+			Patch:    `[{"op":"remove","path":"/base_root/a~0b~1c"},{"op":"remove","path":"/base_root/ids/1"}]`,
+			Expected: `{"base_root":{"wallet":{"0":{"amount":100,"type":"gold"}},"ids":[1,3]}}`,
+		},
+		{
+			Name:     "move and copy",
+			Patch:    `[{"op":"copy","from":"/base_root/wallet/0","path":"/base_root/wallet/1"},{"op":"move","from":"/base_root/ids","path":"/ids"},{"op":"test","path":"/base_root/wallet/1/amount","value":100.0}]`,
+			Expected: `{"base_root":{"wallet":{"0":{"amount":100,"type":"gold"},"1":{"amount":100,"type":"gold"}},"a~b/c":true},"ids":[1,2,3]}`,
+		},
+	}
+
+	for _, c := range testCases {
+		lhm := orderedmap.New()
+		require.NoError(t, json.Unmarshal([]byte(document), lhm))
+		operations, err := DecodeOperations([]byte(c.Patch))
+		require.NoError(t, err, c.Name)
+		result, err := Apply(*lhm, operations)
+		require.NoError(t, err, c.Name)
+		resultBytes, err := json.Marshal(result)
+		require.NoError(t, err, c.Name)
+		assert.Equal(t, c.Expected, string(resultBytes), c.Name)
+
+		// the input document is never modified
+		lhmBytes, err := json.Marshal(lhm)
+		require.NoError(t, err, c.Name)
+		assert.Equal(t, document, string(lhmBytes), c.Name)
+	}
+}
+
+func TestApply_Failed(t *testing.T) {
+	patches := []string{
+		`[{"op":"replace","path":"/base_root/missing","value":1}]`,
+		`[{"op":"remove","path":"/base_root/ids/3"}]`,
+		`[{"op":"add","path":"/base_root/ids/01","value":1}]`,
+		`[{"op":"test","path":"/base_root/wallet/0/type","value":"bust"}]`,
+		`[{"op":"move","from":"/base_root","path":"/base_root/wallet/x"}]`,
+		`[{"op":"add","path":"base_root","value":1}]`,
+		`[{"op":"replace","path":"/base_root"}]`,
+		`[{"op":"unknown","path":"/base_root"}]`,
+	}
+	for _, patch := range patches {
+		lhm := orderedmap.New()
+		require.NoError(t, json.Unmarshal([]byte(document), lhm))
+		operations, err := DecodeOperations([]byte(patch))
+		require.NoError(t, err, patch)
+		_, err = Apply(*lhm, operations)
+		assert.Error(t, err, patch)
+	}
+}
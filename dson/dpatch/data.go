@@ -0,0 +1,83 @@
+// Package dpatch applies JSON Patch (RFC 6902) documents to the ordered map of a decoded DSON file.
+//
+// The ordered map is expected to have the same shape as what `json.Unmarshal` produces: objects are
+// `orderedmap.OrderedMap`, arrays are `[]any`, and numbers are float64.
+package dpatch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type (
+	Operation struct {
+		Op    OperationType   `json:"op"`
+		Path  string          `json:"path"`
+		From  string          `json:"from,omitempty"`
+		Value json.RawMessage `json:"value,omitempty"`
+	}
+	OperationType string
+
+	ErrInvalidOperation struct {
+		Caller string
+		Reason string
+	}
+	ErrInvalidPointer struct {
+		Caller  string
+		Pointer string
+	}
+	ErrPathNotFound struct {
+		Caller string
+		Path   string
+	}
+	ErrTestFailed struct {
+		Caller   string
+		Path     string
+		Expected string
+		Actual   string
+	}
+)
+
+const (
+	OperationTypeAdd     = OperationType("add")
+	OperationTypeRemove  = OperationType("remove")
+	OperationTypeReplace = OperationType("replace")
+	OperationTypeMove    = OperationType("move")
+	OperationTypeCopy    = OperationType("copy")
+	OperationTypeTest    = OperationType("test")
+
+	// TokenEndOfArray is the JSON Pointer token that denotes the position after the last element of an array.
+	TokenEndOfArray = "-"
+)
+
+func (r ErrInvalidOperation) Error() string {
+	msg := fmt.Sprintf(
+		`%s: invalid operation: %s`,
+		r.Caller, r.Reason,
+	)
+	return msg
+}
+
+func (r ErrInvalidPointer) Error() string {
+	msg := fmt.Sprintf(
+		`%s: invalid JSON pointer "%s"`,
+		r.Caller, r.Pointer,
+	)
+	return msg
+}
+
+func (r ErrPathNotFound) Error() string {
+	msg := fmt.Sprintf(
+		`%s: path "%s" not found`,
+		r.Caller, r.Path,
+	)
+	return msg
+}
+
+func (r ErrTestFailed) Error() string {
+	msg := fmt.Sprintf(
+		`%s: test at path "%s" failed: expected "%s"; got "%s"`,
+		r.Caller, r.Path, r.Expected, r.Actual,
+	)
+	return msg
+}
@@ -0,0 +1,86 @@
+package dpatch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+// ParsePointer splits a JSON Pointer (RFC 6901) into its unescaped tokens. The empty pointer denotes the whole
+// document and has no token.
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, ErrInvalidPointer{
+			Caller:  "ParsePointer",
+			Pointer: pointer,
+		}
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		// the order matters: "~01" should become "~1" instead of "/"
+		token = strings.ReplaceAll(token, "~1", "/")
+		token = strings.ReplaceAll(token, "~0", "~")
+		tokens[i] = token
+	}
+	return tokens, nil
+}
+
+func parseArrayIndex(token string, length int, allowEnd bool) (int, bool) {
+	if allowEnd && token == TokenEndOfArray {
+		return length, true
+	}
+	// leading zeroes and signs are not allowed
+	if token == "" || (len(token) > 1 && token[0] == '0') || token[0] < '0' || token[0] > '9' {
+		return 0, false
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil {
+		return 0, false
+	}
+	if index > length || (index == length && !allowEnd) {
+		return 0, false
+	}
+	return index, true
+}
+
+func getChild(container any, token string) (any, bool) {
+	switch container := container.(type) {
+	case orderedmap.OrderedMap:
+		return container.Get(token)
+	case []any:
+		index, ok := parseArrayIndex(token, len(container), false)
+		if !ok {
+			return nil, false
+		}
+		return container[index], true
+	}
+	return nil, false
+}
+
+// Get returns the value at the tokens within the document.
+func Get(document any, tokens []string) (any, bool) {
+	value := document
+	for _, token := range tokens {
+		child, ok := getChild(value, token)
+		if !ok {
+			return nil, false
+		}
+		value = child
+	}
+	return value, true
+}
+
+// copyObject creates a new ordered map with the same keys and values as lhm. It is needed since a copied
+// `orderedmap.OrderedMap` still shares the underlying values with the original one.
+func copyObject(lhm orderedmap.OrderedMap) *orderedmap.OrderedMap {
+	result := orderedmap.New()
+	for _, key := range lhm.Keys() {
+		value, _ := lhm.Get(key)
+		result.Set(key, value)
+	}
+	return result
+}
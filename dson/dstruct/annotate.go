@@ -0,0 +1,109 @@
+package dstruct
+
+import (
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/ds"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dmeta2"
+	"github.com/thanhnguyen2187/darkest-savior/dson/lbytes"
+)
+
+// Annotate collects the data types, the unknown bits, the padded bytes, and the ambiguous names of every field within the file
+// (including the fields of the embedded files). Together with the JSON file, they are enough to encode the exact
+// original bytes with `FromAnnotatedLinkedHashMap`.
+func Annotate(file Struct) Annotations {
+	annotations := Annotations{}
+	annotate(annotations, "", file)
+	return annotations
+}
+
+func annotate(annotations Annotations, parentPointer string, file Struct) {
+	for i, field := range file.Fields {
+		pointer := parentPointer + ToPointer(field.Inferences.HierarchyPath)
+		annotation := Annotation{}
+		if !field.Inferences.IsObject {
+			annotation.DataType = field.Inferences.DataType
+			if ambiguousNames := dfield.AmbiguousNames(field); len(ambiguousNames) > 0 {
+				annotation.AmbiguousNames = ambiguousNames
+			}
+		}
+		if i < len(file.Meta2Block) {
+			annotation.UnknownBits = file.Meta2Block[i].FieldInfo&dmeta2.FieldInfoUnknownBits != 0
+		}
+		paddedBytes := field.RawData[:len(field.RawData)-len(field.Inferences.RawDataStripped)]
+		if lo.SomeBy(paddedBytes, func(b byte) bool { return b != 0 }) {
+			annotation.PaddedBytes = ds.ShallowCopy(paddedBytes)
+		}
+		annotations[pointer] = annotation
+
+		if embeddedFile, ok := field.Inferences.Data.(Struct); ok {
+			annotate(annotations, pointer, embeddedFile)
+		}
+	}
+}
+
+// ToPointer turns a hierarchy path into a JSON Pointer.
+func ToPointer(hierarchyPath []string) string {
+	replacer := strings.NewReplacer("~", "~0", "/", "~1")
+	pointer := ""
+	for _, name := range hierarchyPath {
+		pointer += "/" + replacer.Replace(name)
+	}
+	return pointer
+}
+
+// applyAnnotations sets the unknown bits and the padded bytes of the fields (which are built from a JSON file)
+// by their annotations. The padded bytes are only restored if their length stays the same.
+func applyAnnotations(annotations Annotations, parentPointer string, file Struct) Struct {
+	meta2Block := ds.ShallowCopy(file.Meta2Block)
+	fields := ds.ShallowCopy(file.Fields)
+	for i := range fields {
+		field := &fields[i]
+		pointer := parentPointer + ToPointer(field.Inferences.HierarchyPath)
+		annotation, ok := annotations[pointer]
+		if !ok {
+			continue
+		}
+
+		if embeddedFile, ok := field.Inferences.Data.(Struct); ok {
+			annotatedEmbeddedFile := applyAnnotations(annotations, pointer, embeddedFile)
+			annotatedEmbeddedFileBytes := EncodeStruct(annotatedEmbeddedFile)
+			field.Inferences.Data = annotatedEmbeddedFile
+			field.Inferences.RawDataStripped = append(
+				lbytes.EncodeValueInt(len(annotatedEmbeddedFileBytes)),
+				annotatedEmbeddedFileBytes...,
+			)
+		}
+		paddedBytesCount := len(field.RawData) - len(field.Inferences.RawDataStripped)
+		paddedBytes := lbytes.CreateZeroBytes(paddedBytesCount)
+		if len(annotation.PaddedBytes) == paddedBytesCount {
+			paddedBytes = ds.ShallowCopy(annotation.PaddedBytes)
+		}
+		field.RawData = append(paddedBytes, field.Inferences.RawDataStripped...)
+
+		if annotation.UnknownBits && i < len(meta2Block) {
+			meta2Block[i].FieldInfo |= dmeta2.FieldInfoUnknownBits
+		}
+	}
+
+	file.Meta2Block = meta2Block
+	file.Fields = fields
+	return file
+}
+
+// annotatedDataType returns the annotated data type of the field if it still fits the value,
+// or falls back to implying the data type from the field name, the hierarchy path, and the value.
+func annotatedDataType(
+	annotations Annotations,
+	pointer string,
+	fieldName string,
+	hierarchyPath []string,
+	value any,
+) dfield.DataType {
+	if annotation, ok := annotations[pointer]; ok && dfield.AcceptsValue(annotation.DataType, value) {
+		return annotation.DataType
+	}
+	return dfield.ImplyDataType(fieldName, hierarchyPath, value)
+}
@@ -14,4 +14,17 @@ type (
 		Meta2Block []dmeta2.Entry     `json:"meta_2_block"`
 		Fields     []dfield.DataField `json:"fields"`
 	}
+	// Annotations keep what is lost when a decoded file is turned into JSON, keyed by the JSON Pointer (RFC 6901)
+	// of each field within the JSON file, such as `/base_root/heroes/56/hero_file_data/raw_data/base_root/m_Stress`.
+	Annotations map[string]Annotation
+	Annotation  struct {
+		DataType dfield.DataType `json:"type,omitempty"`
+		// UnknownBits is whether the highest bit of the field's meta 2 entry field info is set.
+		UnknownBits bool `json:"unknown_bits,omitempty"`
+		// PaddedBytes is only kept if they are not all zeroes.
+		PaddedBytes []byte `json:"padded_bytes,omitempty"`
+		// AmbiguousNames are the names that the unhashed value could be, if its hash is shared by several known names.
+		// They are only for reading, since all of them hash back to the same value.
+		AmbiguousNames []string `json:"ambiguous_names,omitempty"`
+	}
 )
@@ -2,6 +2,7 @@ package dstruct
 
 import (
 	"github.com/iancoleman/orderedmap"
+	"github.com/samber/lo"
 	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
 	"github.com/thanhnguyen2187/darkest-savior/dson/dheader"
 	"github.com/thanhnguyen2187/darkest-savior/dson/dmeta1"
@@ -16,15 +17,17 @@ func ToLinkedHashMap(file Struct) *orderedmap.OrderedMap {
 	lhmByIndex[-1].Set(dfield.FieldNameRevision, file.Header.Revision)
 	for index, field := range file.Fields {
 		parentIndex := field.Inferences.ParentIndex
+		// the key is the field name, unless the name is duplicated within the same object
+		key, _ := lo.Last(field.Inferences.HierarchyPath)
 		if field.Inferences.IsObject {
 			lhm := orderedmap.New()
 			lhmByIndex[index] = lhm
-			lhmByIndex[parentIndex].Set(field.Name, lhm)
+			lhmByIndex[parentIndex].Set(key, lhm)
 		} else if field.Inferences.DataType == dfield.DataTypeFileDecoded {
 			lhm := ToLinkedHashMap(field.Inferences.Data.(Struct))
-			lhmByIndex[parentIndex].Set(field.Name, lhm)
+			lhmByIndex[parentIndex].Set(key, lhm)
 		} else {
-			lhmByIndex[parentIndex].Set(field.Name, field.Inferences.Data)
+			lhmByIndex[parentIndex].Set(key, field.Inferences.Data)
 		}
 	}
 
@@ -32,7 +35,23 @@ func ToLinkedHashMap(file Struct) *orderedmap.OrderedMap {
 }
 
 func ToStructuredFile(bs []byte) (*Struct, error) {
-	reader := lbytes.NewBytesReader(bs)
+	return DecodeStruct(lbytes.NewBytesReader(bs))
+}
+
+// ToStructuredFileLeniently works like ToStructuredFile, but keeps the fields whose data cannot be inferred with the
+// unknown data type (see dfield.DecodeFieldsLeniently).
+func ToStructuredFileLeniently(bs []byte) (*Struct, error) {
+	return decodeStruct(lbytes.NewBytesReader(bs), true)
+}
+
+// DecodeStruct reads a whole DSON file from the reader. The sections of a DSON
+// file are laid out sequentially, so the reader is never rewound, which means any
+// `io.Reader` can be used as the source.
+func DecodeStruct(reader *lbytes.Reader) (*Struct, error) {
+	return decodeStruct(reader, false)
+}
+
+func decodeStruct(reader *lbytes.Reader, lenient bool) (*Struct, error) {
 	file := Struct{}
 	err := error(nil)
 
@@ -51,11 +70,14 @@ func ToStructuredFile(bs []byte) (*Struct, error) {
 		return nil, err
 	}
 
-	file.Fields, err = dfield.DecodeFields(reader, file.Meta2Block)
+	if lenient {
+		file.Fields, err = dfield.DecodeFieldsLeniently(reader, file.Meta2Block)
+	} else {
+		file.Fields, err = dfield.DecodeFields(reader, file.Meta2Block)
+	}
 	if err != nil {
 		return nil, err
 	}
-	// file.Fields = dfield.RemoveDuplications(file.Fields)
 
 	for i := range file.Fields {
 		field := &file.Fields[i]
@@ -64,7 +86,7 @@ func ToStructuredFile(bs []byte) (*Struct, error) {
 		// but it would create a circular dependency between the package and `dson`
 		if field.Inferences.DataType == dfield.DataTypeFileRaw {
 			rawDataSkipped := field.Inferences.RawDataStripped[4:]
-			embeddedFile, err := ToStructuredFile(rawDataSkipped)
+			embeddedFile, err := decodeStruct(lbytes.NewBytesReader(rawDataSkipped), lenient)
 			if err != nil {
 				return nil, err
 			}
@@ -1,7 +1,11 @@
 package dstruct
 
 import (
+	"bytes"
+	"io"
+
 	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"github.com/thanhnguyen2187/darkest-savior/ds"
 	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
@@ -13,6 +17,23 @@ import (
 )
 
 func FromLinkedHashMap(lhm orderedmap.OrderedMap) (*Struct, error) {
+	return fromLinkedHashMap(lhm, "", nil)
+}
+
+// FromAnnotatedLinkedHashMap works like FromLinkedHashMap, but it uses the annotations (which are created by
+// `Annotate`) instead of guessing the data types again, and restores the bits that cannot be stored within JSON.
+// Encoding an untouched JSON file with its annotations yields the exact original bytes. A field that has no
+// annotation, or whose value does not fit the annotated data type anymore, is handled like in FromLinkedHashMap.
+func FromAnnotatedLinkedHashMap(lhm orderedmap.OrderedMap, annotations Annotations) (*Struct, error) {
+	file, err := fromLinkedHashMap(lhm, "", annotations)
+	if err != nil {
+		return nil, err
+	}
+	annotatedFile := applyAnnotations(annotations, "", *file)
+	return &annotatedFile, nil
+}
+
+func fromLinkedHashMap(lhm orderedmap.OrderedMap, pointer string, annotations Annotations) (*Struct, error) {
 	lhm = ds.Deref(&lhm)
 	revisionKey := lhm.Keys()[0]
 	if revisionKey != dfield.FieldNameRevision {
@@ -24,7 +45,7 @@ func FromLinkedHashMap(lhm orderedmap.OrderedMap) (*Struct, error) {
 	revisionAny, _ := lhm.Get(revisionKey)
 	revision := int32(revisionAny.(float64))
 
-	dataFields, err := ToDataFields([]string{}, lhm)
+	dataFields, err := toDataFields(pointer, []string{}, lhm, annotations)
 	if err != nil {
 		return nil, err
 	}
@@ -165,24 +186,34 @@ func FromLinkedHashMap(lhm orderedmap.OrderedMap) (*Struct, error) {
 }
 
 func ToDataFields(parentHierarchyPath []string, lhm orderedmap.OrderedMap) ([]dfield.DataField, error) {
+	return toDataFields("", parentHierarchyPath, lhm, nil)
+}
+
+func toDataFields(
+	pointer string,
+	parentHierarchyPath []string,
+	lhm orderedmap.OrderedMap,
+	annotations Annotations,
+) ([]dfield.DataField, error) {
 	dataFields := make([]dfield.DataField, 0, len(lhm.Keys()))
 	for _, key := range lhm.Keys() {
 		if key == dfield.FieldNameRevision {
 			continue
 		}
 		value, _ := lhm.Get(key)
-		fieldName := key
+		fieldName := dfield.FromKey(key)
 		hierarchyPath := ds.ShallowCopy(parentHierarchyPath)
-		hierarchyPath = append(hierarchyPath, fieldName)
+		hierarchyPath = append(hierarchyPath, key)
 		field := dfield.DataField{}
 
 		field.Name = fieldName
 		field.Inferences.HierarchyPath = hierarchyPath
-		dataType := dfield.ImplyDataType(key, hierarchyPath[1:], value)
+		fieldPointer := pointer + ToPointer(hierarchyPath)
+		dataType := annotatedDataType(annotations, fieldPointer, fieldName, hierarchyPath[1:], value)
 		switch dataType {
 		case dfield.DataTypeObject:
 			valueLhm := value.(orderedmap.OrderedMap)
-			childFields, err := ToDataFields(hierarchyPath, valueLhm)
+			childFields, err := toDataFields(pointer, hierarchyPath, valueLhm, annotations)
 			if err != nil {
 				return nil, err
 			}
@@ -193,7 +224,7 @@ func ToDataFields(parentHierarchyPath []string, lhm orderedmap.OrderedMap) ([]df
 			dataFields = append(dataFields, childFields...)
 		case dfield.DataTypeFileJSON:
 			valueLhm := value.(orderedmap.OrderedMap)
-			embeddedStruct, err := FromLinkedHashMap(valueLhm)
+			embeddedStruct, err := fromLinkedHashMap(valueLhm, fieldPointer, annotations)
 			if err != nil {
 				return nil, err
 			}
@@ -218,22 +249,40 @@ func ToDataFields(parentHierarchyPath []string, lhm orderedmap.OrderedMap) ([]df
 	return dataFields, nil
 }
 
-func EncodeStruct(file Struct) []byte {
+func CalculateStructSize(file Struct) int {
 	headerSize := dheader.DefaultHeaderSize
 	meta1Size := int(file.Header.Meta1Size)
 	meta2Size := dmeta2.CalculateBlockSize(int(file.Header.NumMeta2Entries))
 	dataSize := int(file.Header.DataLength)
-	totalSize := headerSize + meta1Size + meta2Size + dataSize
-
-	headerBytes := dheader.Encode(file.Header)
-	meta1Bytes := dmeta1.EncodeBlock(file.Meta1Block)
-	meta2Bytes := dmeta2.EncodeBlock(file.Meta2Block)
-	dataFieldsBytes := dfield.EncodeDataFields(file.Fields)
-
-	bs := make([]byte, 0, totalSize)
-	bs = append(bs, headerBytes...)
-	bs = append(bs, meta1Bytes...)
-	bs = append(bs, meta2Bytes...)
-	bs = append(bs, dataFieldsBytes...)
-	return bs
+	return headerSize + meta1Size + meta2Size + dataSize
+}
+
+func EncodeStruct(file Struct) []byte {
+	buffer := bytes.NewBuffer(make([]byte, 0, CalculateStructSize(file)))
+	// writing to a `bytes.Buffer` never returns an error
+	_ = WriteStruct(buffer, file)
+	return buffer.Bytes()
+}
+
+// WriteStruct writes the encoded sections of a DSON file one after another,
+// without building the whole file in memory first.
+func WriteStruct(writer io.Writer, file Struct) error {
+	sections := []ds.Pair[string, []byte]{
+		ds.NewPair("header", dheader.Encode(file.Header)),
+		ds.NewPair("meta 1 block", dmeta1.EncodeBlock(file.Meta1Block)),
+		ds.NewPair("meta 2 block", dmeta2.EncodeBlock(file.Meta2Block)),
+	}
+	for _, section := range sections {
+		if _, err := writer.Write(section.Second); err != nil {
+			err := errors.Wrapf(err, "WriteStruct error writing %s", section.First)
+			return err
+		}
+	}
+	for _, field := range file.Fields {
+		if _, err := writer.Write(dfield.EncodeDataFields([]dfield.DataField{field})); err != nil {
+			err := errors.Wrapf(err, `WriteStruct error writing field "%s"`, field.Name)
+			return err
+		}
+	}
+	return nil
 }
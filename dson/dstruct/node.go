@@ -0,0 +1,42 @@
+package dstruct
+
+import (
+	"encoding/json"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+)
+
+// ToJSONNode turns a decoded DSON struct into an ordered map that has the same shape as what `json.Unmarshal`
+// produces (numbers are float64, vectors are []any, and objects are `orderedmap.OrderedMap`), which is also the shape
+// that FromLinkedHashMap expects.
+func ToJSONNode(file Struct) (*orderedmap.OrderedMap, error) {
+	lhm := ToLinkedHashMap(file)
+	lhmBytes, err := json.Marshal(lhm)
+	if err != nil {
+		err := errors.Wrap(err, "dstruct.ToJSONNode error marshalling")
+		return nil, err
+	}
+	node := orderedmap.New()
+	if err := json.Unmarshal(lhmBytes, node); err != nil {
+		err := errors.Wrap(err, "dstruct.ToJSONNode error unmarshalling")
+		return nil, err
+	}
+	return node, nil
+}
+
+// FromJSONNode encodes node back into a DSON struct. If the original struct that node was read from is given,
+// its unknown bits are restored (see RestoreUnknownBits), which means an untouched node is encoded into the exact
+// original bytes.
+func FromJSONNode(node orderedmap.OrderedMap, original *Struct) (*Struct, error) {
+	file, err := FromLinkedHashMap(node)
+	if err != nil {
+		err := errors.Wrap(err, "dstruct.FromJSONNode error")
+		return nil, err
+	}
+	if original != nil {
+		restoredFile := RestoreUnknownBits(*original, *file)
+		file = &restoredFile
+	}
+	return file, nil
+}
@@ -0,0 +1,28 @@
+package dstruct
+
+import (
+	"strings"
+)
+
+// PathSeparator is a character that does not appear in field names, which makes joined paths unambiguous.
+const PathSeparator = "\u0000"
+
+// PathKey identifies a field by its path, which is joined with PathSeparator.
+type PathKey struct {
+	Path string
+	// Occurrence tells apart fields that share the same path,
+	// which happens when an object has duplicated keys
+	Occurrence int
+}
+
+// IndexPaths returns the key of each path, in the same order.
+func IndexPaths(paths [][]string) []PathKey {
+	occurrences := map[string]int{}
+	keys := make([]PathKey, 0, len(paths))
+	for _, path := range paths {
+		joinedPath := strings.Join(path, PathSeparator)
+		keys = append(keys, PathKey{Path: joinedPath, Occurrence: occurrences[joinedPath]})
+		occurrences[joinedPath] += 1
+	}
+	return keys
+}
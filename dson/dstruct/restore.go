@@ -0,0 +1,76 @@
+package dstruct
+
+import (
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/ds"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dmeta2"
+	"github.com/thanhnguyen2187/darkest-savior/dson/lbytes"
+)
+
+// RestoreUnknownBits copies the bits that do not seem to carry any meaning, but are written by the game anyway,
+// from the original file into the encoded one:
+//
+//   - The highest bit of each meta 2 entry's field info, and
+//   - The padded bytes before each field's data, which are not always zeroes (especially within embedded files).
+//
+// Fields are matched by their hierarchy paths, so the bits of the fields that were kept are restored,
+// even if other fields were added or removed. Encoding an untouched file this way yields the original bytes.
+func RestoreUnknownBits(original Struct, encoded Struct) Struct {
+	originalIndexes := map[PathKey]int{}
+	for i, key := range IndexPaths(hierarchyPaths(original.Fields)) {
+		originalIndexes[key] = i
+	}
+	encodedIndexes := IndexPaths(hierarchyPaths(encoded.Fields))
+
+	meta2Block := ds.ShallowCopy(encoded.Meta2Block)
+	fields := ds.ShallowCopy(encoded.Fields)
+	for encodedIndex, key := range encodedIndexes {
+		originalIndex, ok := originalIndexes[key]
+		if !ok {
+			continue
+		}
+		if encodedIndex < len(meta2Block) && originalIndex < len(original.Meta2Block) {
+			unknownBits := original.Meta2Block[originalIndex].FieldInfo & dmeta2.FieldInfoUnknownBits
+			meta2Block[encodedIndex].FieldInfo |= unknownBits
+		}
+		fields[encodedIndex] = restoreFieldUnknownBits(original.Fields[originalIndex], fields[encodedIndex])
+	}
+
+	encoded.Meta2Block = meta2Block
+	encoded.Fields = fields
+	return encoded
+}
+
+func restoreFieldUnknownBits(original dfield.DataField, encoded dfield.DataField) dfield.DataField {
+	originalEmbedded, ok1 := original.Inferences.Data.(Struct)
+	encodedEmbedded, ok2 := encoded.Inferences.Data.(Struct)
+	if ok1 && ok2 {
+		restoredEmbedded := RestoreUnknownBits(originalEmbedded, encodedEmbedded)
+		restoredEmbeddedBytes := EncodeStruct(restoredEmbedded)
+		encoded.Inferences.Data = restoredEmbedded
+		encoded.Inferences.RawDataStripped = append(
+			lbytes.EncodeValueInt(len(restoredEmbeddedBytes)),
+			restoredEmbeddedBytes...,
+		)
+	}
+
+	originalPaddedBytes := original.RawData[:len(original.RawData)-len(original.Inferences.RawDataStripped)]
+	encodedPaddedBytesCount := len(encoded.RawData) - len(encoded.Inferences.RawDataStripped)
+	if len(originalPaddedBytes) == encodedPaddedBytesCount {
+		encoded.RawData = append(
+			ds.ShallowCopy(originalPaddedBytes),
+			encoded.Inferences.RawDataStripped...,
+		)
+	} else {
+		encoded.RawData = append(
+			lbytes.CreateZeroBytes(encodedPaddedBytesCount),
+			encoded.Inferences.RawDataStripped...,
+		)
+	}
+	return encoded
+}
+
+func hierarchyPaths(fields []dfield.DataField) [][]string {
+	return lo.Map(fields, func(field dfield.DataField, _ int) []string { return field.Inferences.HierarchyPath })
+}
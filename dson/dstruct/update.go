@@ -0,0 +1,103 @@
+package dstruct
+
+import (
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/ds"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/lbytes"
+)
+
+// UpdateField replaces the field at indexes with the result of f. The first index points to a field of file,
+// and each of the next ones points to a field of the embedded file within the previous field, which means the
+// changes are propagated through the embedded files into the outermost one.
+//
+// The structure of the file (field names and hierarchy) must stay the same; only the data can change.
+func UpdateField(
+	file Struct,
+	indexes []int,
+	f func(dfield.DataField) (dfield.DataField, error),
+) (*Struct, error) {
+	if len(indexes) == 0 || indexes[0] < 0 || indexes[0] >= len(file.Fields) {
+		err := errors.Errorf(`UpdateField error: invalid field indexes "%v"`, indexes)
+		return nil, err
+	}
+	index := indexes[0]
+	field := file.Fields[index]
+	paddedBytes := field.RawData[:len(field.RawData)-len(field.Inferences.RawDataStripped)]
+	if len(indexes) == 1 {
+		updatedField, err := f(field)
+		if err != nil {
+			err := errors.Wrapf(err, `UpdateField error updating field "%s"`, field.Name)
+			return nil, err
+		}
+		field = updatedField
+	} else {
+		embeddedFile, ok := field.Inferences.Data.(Struct)
+		if !ok {
+			err := errors.Errorf(`UpdateField error: field "%s" is not an embedded file`, field.Name)
+			return nil, err
+		}
+		updatedEmbeddedFile, err := UpdateField(embeddedFile, indexes[1:], f)
+		if err != nil {
+			return nil, err
+		}
+		updatedEmbeddedFileBytes := EncodeStruct(*updatedEmbeddedFile)
+		field.Inferences.Data = *updatedEmbeddedFile
+		field.Inferences.RawDataStripped = append(
+			lbytes.EncodeValueInt(len(updatedEmbeddedFileBytes)),
+			updatedEmbeddedFileBytes...,
+		)
+	}
+
+	// the padded bytes are kept, so Relayout can tell whether they need to be recalculated
+	field.RawData = append(ds.ShallowCopy(paddedBytes), field.Inferences.RawDataStripped...)
+	fields := ds.ShallowCopy(file.Fields)
+	fields[index] = field
+	file.Fields = fields
+	file = Relayout(file)
+	return &file, nil
+}
+
+// Relayout recalculates the offsets, padded bytes, and data length of the file after the data of some fields
+// changed. Unlike `FromLinkedHashMap`, nothing is implied again, so the data types and the unknown bits stay.
+func Relayout(file Struct) Struct {
+	fieldNameLengths := lo.Map(
+		file.Fields,
+		func(t dfield.DataField, _ int) int { return len(t.Name) + 1 },
+	)
+	rawDataStrippedLengths := lo.Map(
+		file.Fields,
+		func(t dfield.DataField, _ int) int { return len(t.Inferences.RawDataStripped) },
+	)
+	meta2Offsets := dfield.CalculateMeta2OffsetsV2(fieldNameLengths, rawDataStrippedLengths)
+	meta2OffsetsDropped := lo.DropRight(meta2Offsets, 1)
+	paddedBytesCounts := dfield.CalculatePaddedBytesCountsV2(
+		fieldNameLengths,
+		meta2OffsetsDropped,
+		rawDataStrippedLengths,
+	)
+
+	fields := ds.ShallowCopy(file.Fields)
+	meta2Block := ds.ShallowCopy(file.Meta2Block)
+	for i := range fields {
+		field := &fields[i]
+		paddedBytes := field.RawData[:len(field.RawData)-len(field.Inferences.RawDataStripped)]
+		if len(paddedBytes) != paddedBytesCounts[i] {
+			paddedBytes = lbytes.CreateZeroBytes(paddedBytesCounts[i])
+		}
+		field.RawData = append(ds.ShallowCopy(paddedBytes), field.Inferences.RawDataStripped...)
+		field.Inferences.RawDataOffset = meta2OffsetsDropped[i] + fieldNameLengths[i]
+		field.Inferences.RawDataLength = len(field.RawData)
+		if i < len(meta2Block) {
+			meta2Block[i].Offset = int32(meta2OffsetsDropped[i])
+			meta2Block[i].Inferences.RawDataLength = len(field.RawData)
+		}
+	}
+
+	dataLength, _ := lo.Last(meta2Offsets)
+	file.Header.DataLength = int32(dataLength)
+	file.Meta2Block = meta2Block
+	file.Fields = fields
+	return file
+}
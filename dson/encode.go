@@ -1,23 +1,29 @@
 package dson
 
 import (
-	"encoding/json"
-
-	"github.com/iancoleman/orderedmap"
-	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"bytes"
 )
 
 func EncodeJSON(fileBytes []byte) ([]byte, error) {
 	// TODO: add `debug` parameter
-	lhm := orderedmap.New()
-	err := json.Unmarshal(fileBytes, lhm)
-	if err != nil {
+	buffer := bytes.Buffer{}
+	if err := NewEncoder(&buffer).EncodeJSON(bytes.NewReader(fileBytes)); err != nil {
 		return nil, err
 	}
-	dsonStruct, err := dstruct.FromLinkedHashMap(*lhm)
+	return buffer.Bytes(), nil
+}
+
+// EncodeJSONAnnotated works like EncodeJSON, but uses the annotations that were returned by DecodeDSONAnnotated.
+func EncodeJSONAnnotated(fileBytes []byte, annotationsBytes []byte) ([]byte, error) {
+	annotations, err := ReadAnnotations(bytes.NewReader(annotationsBytes))
 	if err != nil {
 		return nil, err
 	}
-	resultBytes := dstruct.EncodeStruct(*dsonStruct)
-	return resultBytes, nil
+	buffer := bytes.Buffer{}
+	encoder := NewEncoder(&buffer)
+	encoder.SetAnnotations(annotations)
+	if err := encoder.EncodeJSON(bytes.NewReader(fileBytes)); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
 }
@@ -37,7 +37,7 @@ func (suite *EndToEndTestSuite2) SetupSuite() {
 		"../sample_dson/persist.game_knowledge.json",
 		"../sample_dson/persist.journal.json",
 		"../sample_dson/persist.narration.json",
-		// "../sample_data/persist.progression.json", // has duplicated field
+		"../sample_dson/persist.progression.json", // has duplicated field
 		"../sample_dson/persist.quest.json",
 		"../sample_dson/persist.roster.json", // has embedded DSON file
 		"../sample_dson/persist.town_event.json",
@@ -90,7 +90,7 @@ func (suite *EndToEndTestSuite2) SetupSuite() {
 	)
 }
 
-func (suite *EndToEndTestSuite2) TestMeta2Block(filePath string, expected []dmeta2.Entry, actual []dmeta2.Entry) {
+func (suite *EndToEndTestSuite2) checkMeta2Block(filePath string, expected []dmeta2.Entry, actual []dmeta2.Entry) {
 	suite.R.Equal(len(expected), len(actual))
 	lo.ForEach(
 		lo.Zip2(expected, actual),
@@ -112,7 +112,7 @@ func (suite *EndToEndTestSuite2) TestMeta2Block(filePath string, expected []dmet
 	)
 }
 
-func (suite *EndToEndTestSuite2) TestEmbeddedDataFields(filePath string, expected []dfield.DataField, actual []dfield.DataField) {
+func (suite *EndToEndTestSuite2) checkEmbeddedDataFields(filePath string, expected []dfield.DataField, actual []dfield.DataField) {
 	suite.R.Equal(len(expected), len(actual))
 	lo.ForEach(
 		lo.Zip2(expected, actual),
@@ -152,8 +152,8 @@ func (suite *EndToEndTestSuite2) TestEncodeDecode_DataFields() {
 					if ok1 && ok2 {
 						suite.R.Equalf(embeddedStructExpected.Header, embeddedStructActual.Header, filePath)
 						suite.R.Equalf(embeddedStructExpected.Meta1Block, embeddedStructActual.Meta1Block, filePath)
-						suite.TestMeta2Block(filePath, embeddedStructExpected.Meta2Block, embeddedStructActual.Meta2Block)
-						suite.TestEmbeddedDataFields(filePath, embeddedStructExpected.Fields, embeddedStructActual.Fields)
+						suite.checkMeta2Block(filePath, embeddedStructExpected.Meta2Block, embeddedStructActual.Meta2Block)
+						suite.checkEmbeddedDataFields(filePath, embeddedStructExpected.Fields, embeddedStructActual.Fields)
 					} else if !ok1 && !ok2 {
 						suite.R.Equalf(fieldExpected.Name, fieldActual.Name, filePath)
 						suite.R.Equalf(fieldExpected.Inferences.RawDataStripped, fieldActual.Inferences.RawDataStripped, filePath)
@@ -175,7 +175,7 @@ func (suite *EndToEndTestSuite2) TestEncodeDecode_Meta2Block() {
 			filePath := tuple.A
 			decodedStruct := tuple.B
 			encodingStruct := tuple.C
-			suite.TestMeta2Block(
+			suite.checkMeta2Block(
 				filePath,
 				decodedStruct.Meta2Block,
 				encodingStruct.Meta2Block,
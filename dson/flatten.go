@@ -0,0 +1,53 @@
+package dson
+
+import (
+	"strings"
+
+	"github.com/thanhnguyen2187/darkest-savior/ds"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	// FlatField is a field with its full path, which goes through the embedded files.
+	//
+	// For example, the name of a hero within `persist.roster.json` has this path:
+	//
+	//   base_root heroes 56 hero_file_data raw_data base_root actor name
+	FlatField struct {
+		Path []string
+		// Indexes are the indexes of the field within each file, from the outermost one to the embedded one that
+		// directly holds it.
+		Indexes []int
+		Field   dfield.DataField
+	}
+)
+
+// IsContainer reports whether the field holds other fields, which is either an object or an embedded file.
+func (r FlatField) IsContainer() bool {
+	return r.Field.Inferences.IsObject ||
+		r.Field.Inferences.DataType == dfield.DataTypeFileDecoded
+}
+
+func (r FlatField) PathString() string {
+	return strings.Join(r.Path, ".")
+}
+
+// Flatten lists every field of the file, including the fields of the embedded files,
+// which come right after the field that holds them.
+func Flatten(file dstruct.Struct) []FlatField {
+	return flatten([]string{}, []int{}, file)
+}
+
+func flatten(parentPath []string, parentIndexes []int, file dstruct.Struct) []FlatField {
+	flatFields := make([]FlatField, 0, len(file.Fields))
+	for i, field := range file.Fields {
+		path := append(ds.ShallowCopy(parentPath), field.Inferences.HierarchyPath...)
+		indexes := append(ds.ShallowCopy(parentIndexes), i)
+		flatFields = append(flatFields, FlatField{Path: path, Indexes: indexes, Field: field})
+		if embeddedFile, ok := field.Inferences.Data.(dstruct.Struct); ok {
+			flatFields = append(flatFields, flatten(path, indexes, embeddedFile)...)
+		}
+	}
+	return flatFields
+}
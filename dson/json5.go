@@ -0,0 +1,190 @@
+package dson
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// MarshalJSON5 writes the file like the indented JSON of `dstruct.ToLinkedHashMap`, but each value has a trailing
+// comment with its data type, and the original hash if the value was unhashed, such as:
+//
+//	"damage_source_data": "###snake_cobra_A", // string (hash: -1107871272)
+//
+// The result is JSON5, which can be read back with UnmarshalJSON5.
+func MarshalJSON5(file dstruct.Struct) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	if err := writeJSON5File(&buffer, file, "", ""); err != nil {
+		err := errors.Wrap(err, "dson.MarshalJSON5 error")
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// writeJSON5File writes the file as an object, whose opening brace is assumed to be at the given indentation. The
+// comment (if any) is put after the opening brace.
+func writeJSON5File(buffer *bytes.Buffer, file dstruct.Struct, indent string, comment string) error {
+	childIndexesByIndex := make(map[int][]int)
+	for index, field := range file.Fields {
+		parentIndex := field.Inferences.ParentIndex
+		childIndexesByIndex[parentIndex] = append(childIndexesByIndex[parentIndex], index)
+	}
+
+	buffer.WriteString("{")
+	if comment != "" {
+		buffer.WriteString(" // " + comment)
+	}
+	buffer.WriteString("\n")
+	buffer.WriteString(fmt.Sprintf(`%s  "%s": %d`, indent, dfield.FieldNameRevision, file.Header.Revision))
+	if len(childIndexesByIndex[-1]) > 0 {
+		buffer.WriteString(",")
+	}
+	buffer.WriteString("\n")
+	if err := writeJSON5Fields(buffer, file, childIndexesByIndex, -1, indent+"  "); err != nil {
+		return err
+	}
+	buffer.WriteString(indent + "}")
+	return nil
+}
+
+func writeJSON5Fields(
+	buffer *bytes.Buffer,
+	file dstruct.Struct,
+	childIndexesByIndex map[int][]int,
+	parentIndex int,
+	indent string,
+) error {
+	childIndexes := childIndexesByIndex[parentIndex]
+	for i, index := range childIndexes {
+		field := file.Fields[index]
+		separator := ","
+		if i == len(childIndexes)-1 {
+			separator = ""
+		}
+		// the key is the field name, unless the name is duplicated within the same object
+		key, _ := lo.Last(field.Inferences.HierarchyPath)
+		keyBytes, err := json.Marshal(key)
+		if err != nil {
+			return err
+		}
+		buffer.WriteString(indent + string(keyBytes) + ": ")
+
+		switch {
+		case field.Inferences.IsObject && len(childIndexesByIndex[index]) == 0:
+			buffer.WriteString("{}" + separator + "\n")
+		case field.Inferences.IsObject:
+			buffer.WriteString("{\n")
+			if err := writeJSON5Fields(buffer, file, childIndexesByIndex, index, indent+"  "); err != nil {
+				return err
+			}
+			buffer.WriteString(indent + "}" + separator + "\n")
+		case field.Inferences.DataType == dfield.DataTypeFileDecoded:
+			embeddedFile := field.Inferences.Data.(dstruct.Struct)
+			if err := writeJSON5File(buffer, embeddedFile, indent, string(dfield.DataTypeFileDecoded)); err != nil {
+				return err
+			}
+			buffer.WriteString(separator + "\n")
+		default:
+			valueBytes, err := json.Marshal(field.Inferences.Data)
+			if err != nil {
+				return errors.Wrapf(err, `error marshalling field "%s"`, strings.Join(field.Inferences.HierarchyPath, "."))
+			}
+			buffer.WriteString(string(valueBytes) + separator + " // " + DescribeDataType(field) + "\n")
+		}
+	}
+	return nil
+}
+
+// DescribeDataType returns the data type of the field, and the original hashes if the value was unhashed (the name
+// was found from the hashed integer). If a hash is shared by several known names, all of them are listed, since the
+// chosen one might not be the right one.
+func DescribeDataType(field dfield.DataField) string {
+	dataType := field.Inferences.DataType
+	hashes := dfield.UnhashedValues(field)
+	description := string(dataType)
+	switch {
+	case hashes == nil:
+		return description
+	case dataType == dfield.DataTypeString:
+		description = fmt.Sprintf("%s (hash: %d)", dataType, hashes[0])
+	default:
+		hashStrings := lo.Map(hashes, func(hash int32, _ int) string { return fmt.Sprint(hash) })
+		description = fmt.Sprintf("%s (hashes: %s)", dataType, strings.Join(hashStrings, ", "))
+	}
+	if ambiguousNames := dfield.AmbiguousNames(field); len(ambiguousNames) > 0 {
+		description += fmt.Sprintf(" (ambiguous: %s)", strings.Join(ambiguousNames, ", "))
+	}
+	return description
+}
+
+// UnmarshalJSON5 reads the subset of JSON5 that is written by MarshalJSON5 (JSON with comments and trailing commas)
+// into an ordered map.
+func UnmarshalJSON5(bs []byte) (*orderedmap.OrderedMap, error) {
+	lhm := orderedmap.New()
+	if err := json.Unmarshal(StripJSON5(bs), lhm); err != nil {
+		err := errors.Wrap(err, "dson.UnmarshalJSON5 error")
+		return nil, err
+	}
+	return lhm, nil
+}
+
+// StripJSON5 removes the comments and the trailing commas from the JSON5 document, which gives a JSON document.
+func StripJSON5(bs []byte) []byte {
+	result := make([]byte, 0, len(bs))
+	// lastCommaIndex is the index within the result of the last comma that was only followed by whitespaces
+	lastCommaIndex := -1
+	for i := 0; i < len(bs); i++ {
+		b := bs[i]
+		switch {
+		case b == '"':
+			// copy the whole string, including its escaped characters
+			j := i + 1
+			for ; j < len(bs) && bs[j] != '"'; j++ {
+				if bs[j] == '\\' {
+					j++
+				}
+			}
+			end := j + 1
+			if end > len(bs) {
+				end = len(bs)
+			}
+			result = append(result, bs[i:end]...)
+			i = end - 1
+			lastCommaIndex = -1
+		case b == '/' && i+1 < len(bs) && bs[i+1] == '/':
+			for i < len(bs) && bs[i] != '\n' {
+				i++
+			}
+			i--
+		case b == '/' && i+1 < len(bs) && bs[i+1] == '*':
+			end := bytes.Index(bs[i+2:], []byte("*/"))
+			if end < 0 {
+				i = len(bs)
+			} else {
+				i += 2 + end + 1
+			}
+		case b == ']' || b == '}':
+			if lastCommaIndex >= 0 {
+				result[lastCommaIndex] = ' '
+			}
+			result = append(result, b)
+			lastCommaIndex = -1
+		case b == ',':
+			lastCommaIndex = len(result)
+			result = append(result, b)
+		case b == ' ' || b == '\t' || b == '\n' || b == '\r':
+			result = append(result, b)
+		default:
+			result = append(result, b)
+			lastCommaIndex = -1
+		}
+	}
+	return result
+}
@@ -0,0 +1,69 @@
+package dson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSON5RoundTrip(t *testing.T) {
+	filePaths, err := filepath.Glob("../sample_dson/*.json")
+	require.NoError(t, err)
+	for _, filePath := range filePaths {
+		fileBytes, err := ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+
+		// converting through JSON5 should give the same result as converting through JSON
+		jsonBytes, err := DecodeDSON(fileBytes, false)
+		require.NoError(t, err, filePath)
+		expectedBytes, err := EncodeJSON(jsonBytes)
+		require.NoError(t, err, filePath)
+
+		json5Buffer := bytes.Buffer{}
+		decoder := NewDecoder(bytes.NewReader(fileBytes))
+		decoder.SetFormat(FormatJSON5)
+		require.NoError(t, decoder.DecodeJSON(&json5Buffer), filePath)
+
+		dsonBuffer := bytes.Buffer{}
+		encoder := NewEncoder(&dsonBuffer)
+		encoder.SetFormat(FormatJSON5)
+		require.NoError(t, encoder.EncodeJSON(&json5Buffer), filePath)
+		require.Equal(t, expectedBytes, dsonBuffer.Bytes(), filePath)
+	}
+}
+
+func TestMarshalJSON5(t *testing.T) {
+	file := readStruct(t, "../sample_dson/persist.roster.json")
+	json5Bytes, err := MarshalJSON5(*file)
+	require.NoError(t, err)
+
+	require.Contains(t, string(json5Bytes), "\n          \"raw_data\": { // file_decoded\n")
+	require.Contains(t, string(json5Bytes), "\n                \"current_hp\": 37, // float\n")
+	require.Contains(t, string(json5Bytes), "\n                \"damage_source_data\": \"###snake_cobra_A\", // string (hash: -1107871272)\n")
+	require.Contains(t, string(json5Bytes), "\n              \"dungeon_history\": [\"###cove\",\"###weald\",\"###crypts\"] // string_vector (hashes: 15056977, 954282113, -630469331)\n")
+}
+
+func TestStripJSON5(t *testing.T) {
+	json5Bytes := []byte(`{
+  "a": "// not a comment, \"/* either */\"", // a comment
+  /* another
+     comment */
+  "b": [1, 2,], // trailing comma
+  "c": {"d": true,},
+}`)
+	value := map[string]any{}
+	require.NoError(t, json.Unmarshal(StripJSON5(json5Bytes), &value))
+	require.Equal(
+		t,
+		map[string]any{
+			"a": `// not a comment, "/* either */"`,
+			"b": []any{float64(1), float64(2)},
+			"c": map[string]any{"d": true},
+		},
+		value,
+	)
+}
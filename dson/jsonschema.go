@@ -0,0 +1,220 @@
+package dson
+
+import (
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	// JSONSchema is the subset of JSON Schema (draft 7) that is needed to describe converted DSON files.
+	JSONSchema struct {
+		Schema      string `json:"$schema,omitempty"`
+		Ref         string `json:"$ref,omitempty"`
+		Title       string `json:"title,omitempty"`
+		Description string `json:"description,omitempty"`
+		Type        string `json:"type,omitempty"`
+		// Definitions and Properties are ordered maps of *JSONSchema.
+		Definitions          *orderedmap.OrderedMap `json:"definitions,omitempty"`
+		Properties           *orderedmap.OrderedMap `json:"properties,omitempty"`
+		AdditionalProperties *JSONSchema            `json:"additionalProperties,omitempty"`
+		Required             []string               `json:"required,omitempty"`
+		Items                *JSONSchema            `json:"items,omitempty"`
+		MinItems             *int                   `json:"minItems,omitempty"`
+		MaxItems             *int                   `json:"maxItems,omitempty"`
+		MinLength            *int                   `json:"minLength,omitempty"`
+		MaxLength            *int                   `json:"maxLength,omitempty"`
+		Minimum              *float64               `json:"minimum,omitempty"`
+		Maximum              *float64               `json:"maximum,omitempty"`
+		Pattern              string                 `json:"pattern,omitempty"`
+		AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	}
+	// shape is what the values of the same key look like across the decoded files.
+	shape struct {
+		// dataTypes are the stored data types (see dfield.InferDataType) of the values that are not objects.
+		dataTypes  []dfield.DataType
+		isObject   bool
+		isFile     bool
+		keys       []string
+		childByKey map[string]*shape
+	}
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// SaveKind returns the kind of save from the path of a DSON file, such as "roster" for "persist.roster.json", or
+// "novelty_tracker" for "novelty_tracker.json".
+func SaveKind(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.TrimPrefix(name, "persist.")
+}
+
+// ExportJSONSchema describes the converted JSON files of the same save kind, with the fields that are seen within the
+// decoded files, and the data types that are known by `dfield`. Objects whose keys are all numbers (IDs) can have any
+// number of entries.
+func ExportJSONSchema(title string, files []dstruct.Struct) JSONSchema {
+	root := newShape()
+	for _, file := range files {
+		root.mergeFile(file)
+	}
+	schema := root.toJSONSchema()
+	schema.Schema = jsonSchemaDraft
+	schema.Title = title
+	schema.Definitions = dataTypeDefinitions()
+	return *schema
+}
+
+func newShape() *shape {
+	return &shape{
+		dataTypes:  make([]dfield.DataType, 0),
+		keys:       make([]string, 0),
+		childByKey: make(map[string]*shape),
+	}
+}
+
+func (s *shape) child(key string) *shape {
+	child, ok := s.childByKey[key]
+	if !ok {
+		child = newShape()
+		s.childByKey[key] = child
+		s.keys = append(s.keys, key)
+	}
+	return child
+}
+
+func (s *shape) addDataType(dataType dfield.DataType) {
+	if !lo.Contains(s.dataTypes, dataType) {
+		s.dataTypes = append(s.dataTypes, dataType)
+	}
+}
+
+func (s *shape) mergeFile(file dstruct.Struct) {
+	s.isObject = true
+	s.isFile = true
+	s.child(dfield.FieldNameRevision).addDataType(dfield.DataTypeInt)
+	shapeByIndex := map[int]*shape{-1: s}
+	for index, field := range file.Fields {
+		key, _ := lo.Last(field.Inferences.HierarchyPath)
+		child := shapeByIndex[field.Inferences.ParentIndex].child(key)
+		switch {
+		case field.Inferences.IsObject:
+			child.isObject = true
+			shapeByIndex[index] = child
+		case field.Inferences.DataType == dfield.DataTypeFileDecoded:
+			child.mergeFile(field.Inferences.Data.(dstruct.Struct))
+		default:
+			// the stored data type is used, since unhashed values are still stored as integers
+			child.addDataType(dfield.InferDataType(field))
+		}
+	}
+}
+
+// merge returns a new shape that has what both of the shapes have.
+func (s *shape) merge(other *shape) *shape {
+	merged := newShape()
+	for _, source := range []*shape{s, other} {
+		merged.isObject = merged.isObject || source.isObject
+		merged.isFile = merged.isFile || source.isFile
+		for _, dataType := range source.dataTypes {
+			merged.addDataType(dataType)
+		}
+		for _, key := range source.keys {
+			mergedChild, ok := merged.childByKey[key]
+			if !ok {
+				merged.keys = append(merged.keys, key)
+				merged.childByKey[key] = source.childByKey[key]
+				continue
+			}
+			merged.childByKey[key] = mergedChild.merge(source.childByKey[key])
+		}
+	}
+	return merged
+}
+
+// isIDKey tells whether the key is a number, which is mostly an ID, like the keys of the heroes within the roster.
+func isIDKey(key string) bool {
+	fieldName := dfield.FromKey(key)
+	return fieldName != "" && strings.Trim(fieldName, "0123456789") == ""
+}
+
+func (s *shape) toJSONSchema() *JSONSchema {
+	schemas := lo.Map(
+		s.dataTypes,
+		func(dataType dfield.DataType, _ int) *JSONSchema {
+			if dataType == dfield.DataTypeUnknown {
+				// an empty schema allows any value
+				return &JSONSchema{}
+			}
+			return &JSONSchema{Ref: "#/definitions/" + string(dataType)}
+		},
+	)
+	if s.isObject {
+		schemas = append(schemas, s.toObjectJSONSchema())
+	}
+	if len(schemas) == 1 {
+		return schemas[0]
+	}
+	return &JSONSchema{AnyOf: schemas}
+}
+
+func (s *shape) toObjectJSONSchema() *JSONSchema {
+	schema := &JSONSchema{Type: "object"}
+	if s.isFile {
+		schema.Description = "embedded file"
+		schema.Required = []string{dfield.FieldNameRevision}
+	}
+	if len(s.keys) > 0 && lo.EveryBy(s.keys, isIDKey) {
+		entry := newShape()
+		for _, key := range s.keys {
+			entry = entry.merge(s.childByKey[key])
+		}
+		schema.AdditionalProperties = entry.toJSONSchema()
+		return schema
+	}
+	schema.Properties = orderedmap.New()
+	for _, key := range s.keys {
+		schema.Properties.Set(key, s.childByKey[key].toJSONSchema())
+	}
+	return schema
+}
+
+// dataTypeDefinitions describe the JSON values of each data type. An integer can be written as a hashed name (such
+// as "###crusader"), since it is hashed back when encoding.
+func dataTypeDefinitions() *orderedmap.OrderedMap {
+	one := 1
+	two := 2
+	minInt32 := float64(math.MinInt32)
+	maxInt32 := float64(math.MaxInt32)
+	hashedName := &JSONSchema{Type: "string", Pattern: "^###"}
+	integer := &JSONSchema{
+		AnyOf: []*JSONSchema{
+			{Type: "integer", Minimum: &minInt32, Maximum: &maxInt32},
+			hashedName,
+		},
+	}
+	definitions := []lo.Tuple2[dfield.DataType, *JSONSchema]{
+		{A: dfield.DataTypeBool, B: &JSONSchema{Type: "boolean"}},
+		{A: dfield.DataTypeChar, B: &JSONSchema{Type: "string", MinLength: &one, MaxLength: &one}},
+		{A: dfield.DataTypeInt, B: integer},
+		{A: dfield.DataTypeFloat, B: &JSONSchema{Type: "number"}},
+		{A: dfield.DataTypeString, B: &JSONSchema{Type: "string"}},
+		{A: dfield.DataTypeIntVector, B: &JSONSchema{Type: "array", Items: integer}},
+		{A: dfield.DataTypeFloatVector, B: &JSONSchema{Type: "array", Items: &JSONSchema{Type: "number"}}},
+		{A: dfield.DataTypeStringVector, B: &JSONSchema{Type: "array", Items: &JSONSchema{Type: "string"}}},
+		{A: dfield.DataTypeHybridVector, B: &JSONSchema{Type: "array", Items: integer}},
+		{A: dfield.DataTypeTwoBool, B: &JSONSchema{Type: "array", Items: &JSONSchema{Type: "boolean"}, MinItems: &two, MaxItems: &two}},
+		{A: dfield.DataTypeTwoInt, B: &JSONSchema{Type: "array", Items: integer, MinItems: &two, MaxItems: &two}},
+	}
+	lhm := orderedmap.New()
+	for _, definition := range definitions {
+		schema := *definition.B
+		schema.Title = string(definition.A)
+		lhm.Set(string(definition.A), &schema)
+	}
+	return lhm
+}
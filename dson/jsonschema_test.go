@@ -0,0 +1,172 @@
+package dson
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func TestSaveKind(t *testing.T) {
+	assert.Equal(t, "roster", SaveKind("saves/profile_0/persist.roster.json"))
+	assert.Equal(t, "novelty_tracker", SaveKind("novelty_tracker.json"))
+}
+
+// validateJSONSchema checks the value against the subset of JSON Schema that ExportJSONSchema uses, and returns the
+// paths of the values that do not match.
+func validateJSONSchema(root map[string]any, schema map[string]any, value any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		definitionName := strings.TrimPrefix(ref, "#/definitions/")
+		definition := root["definitions"].(map[string]any)[definitionName].(map[string]any)
+		return validateJSONSchema(root, definition, value, path)
+	}
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		for _, subSchema := range anyOf {
+			if len(validateJSONSchema(root, subSchema.(map[string]any), value, path)) == 0 {
+				return nil
+			}
+		}
+		return []string{path + ": no match within anyOf"}
+	}
+	invalid := []string{fmt.Sprintf("%s: %v does not match %v", path, value, schema)}
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return invalid
+		}
+		required, _ := schema["required"].([]any)
+		for _, key := range required {
+			if _, ok := object[key.(string)]; !ok {
+				return invalid
+			}
+		}
+		errs := make([]string, 0)
+		properties, _ := schema["properties"].(map[string]any)
+		for key, subValue := range object {
+			subSchema, ok := properties[key].(map[string]any)
+			if !ok {
+				subSchema, ok = schema["additionalProperties"].(map[string]any)
+			}
+			if ok {
+				errs = append(errs, validateJSONSchema(root, subSchema, subValue, path+"."+key)...)
+			}
+		}
+		return errs
+	case "array":
+		array, ok := value.([]any)
+		if !ok ||
+			schema["minItems"] != nil && len(array) < int(schema["minItems"].(float64)) ||
+			schema["maxItems"] != nil && len(array) > int(schema["maxItems"].(float64)) {
+			return invalid
+		}
+		errs := make([]string, 0)
+		for i, item := range array {
+			errs = append(errs, validateJSONSchema(root, schema["items"].(map[string]any), item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+		return errs
+	case "string":
+		s, ok := value.(string)
+		if !ok ||
+			schema["minLength"] != nil && len(s) < int(schema["minLength"].(float64)) ||
+			schema["maxLength"] != nil && len(s) > int(schema["maxLength"].(float64)) ||
+			schema["pattern"] != nil && !regexp.MustCompile(schema["pattern"].(string)).MatchString(s) {
+			return invalid
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok ||
+			schema["type"] == "integer" && number != math.Trunc(number) ||
+			schema["minimum"] != nil && number < schema["minimum"].(float64) ||
+			schema["maximum"] != nil && number > schema["maximum"].(float64) {
+			return invalid
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return invalid
+		}
+	}
+	return nil
+}
+
+func exportGenericJSONSchema(t *testing.T, title string, files []dstruct.Struct) map[string]any {
+	schemaBytes, err := json.Marshal(ExportJSONSchema(title, files))
+	require.NoError(t, err)
+	schema := make(map[string]any)
+	require.NoError(t, json.Unmarshal(schemaBytes, &schema))
+	return schema
+}
+
+func TestExportJSONSchema_Roster(t *testing.T) {
+	file := readStruct(t, "../sample_dson/persist.roster.json")
+	schema := exportGenericJSONSchema(t, "roster", []dstruct.Struct{*file})
+
+	assert.Equal(t, "roster", schema["title"])
+	assert.Equal(t, []any{dfield.FieldNameRevision}, schema["required"])
+	baseRoot := schema["properties"].(map[string]any)["base_root"].(map[string]any)
+	heroes := baseRoot["properties"].(map[string]any)["heroes"].(map[string]any)
+	require.Nil(t, heroes["properties"])
+	hero := heroes["additionalProperties"].(map[string]any)
+	rawData := hero["properties"].(map[string]any)["hero_file_data"].(map[string]any)["properties"].(map[string]any)["raw_data"].(map[string]any)
+	assert.Equal(t, "embedded file", rawData["description"])
+	assert.Equal(t, []any{dfield.FieldNameRevision}, rawData["required"])
+	heroBaseRoot := rawData["properties"].(map[string]any)["base_root"].(map[string]any)
+	actor := heroBaseRoot["properties"].(map[string]any)["actor"].(map[string]any)
+	currentHP := actor["properties"].(map[string]any)["current_hp"].(map[string]any)
+	assert.Equal(t, "#/definitions/float", currentHP["$ref"])
+}
+
+func TestExportJSONSchema_ValidateSamples(t *testing.T) {
+	filePaths, err := filepath.Glob("../sample_dson/*.json")
+	require.NoError(t, err)
+	for _, filePath := range filePaths {
+		file := readStruct(t, filePath)
+		schema := exportGenericJSONSchema(t, SaveKind(filePath), []dstruct.Struct{*file})
+
+		fileBytes, err := ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+		jsonBytes, err := DecodeDSON(fileBytes, false)
+		require.NoError(t, err)
+		value := make(map[string]any)
+		require.NoError(t, json.Unmarshal(jsonBytes, &value))
+		require.Empty(t, validateJSONSchema(schema, schema, value, "$"), filePath)
+	}
+
+	file := readStruct(t, "../sample_dson/persist.roster.json")
+	schema := exportGenericJSONSchema(t, "roster", []dstruct.Struct{*file})
+	value := map[string]any{
+		dfield.FieldNameRevision: float64(1),
+		"base_root": map[string]any{
+			"heroes": map[string]any{
+				"1": map[string]any{
+					"hero_file_data": map[string]any{
+						"raw_data": map[string]any{
+							dfield.FieldNameRevision: float64(1),
+							"base_root": map[string]any{
+								"actor": map[string]any{"current_hp": "full"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(
+		t,
+		[]string{"$.base_root.heroes.1.hero_file_data.raw_data.base_root.actor.current_hp"},
+		lo.Map(
+			validateJSONSchema(schema, schema, value, "$"),
+			func(err string, _ int) string { return strings.Split(err, ":")[0] },
+		),
+	)
+}
@@ -1,12 +1,12 @@
 package lbytes
 
 import (
-	"bytes"
+	"io"
 )
 
 type (
 	Reader struct {
-		bytes.Reader
+		io.Reader
 	}
 	Instruction struct {
 		Key          string
@@ -1,19 +1,32 @@
 package lbytes
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/binary"
+	"io"
 )
 
 func NewBytesReader(bs []byte) *Reader {
 	return &Reader{
-		Reader: *bytes.NewReader(bs),
+		Reader: bytes.NewReader(bs),
+	}
+}
+
+// NewReader wraps r with a buffered reader if it is not buffered yet,
+// since decoding a DSON file means doing a lot of small reads.
+func NewReader(r io.Reader) *Reader {
+	switch r.(type) {
+	case *bytes.Reader, *bufio.Reader:
+		return &Reader{Reader: r}
+	}
+	return &Reader{
+		Reader: bufio.NewReader(r),
 	}
 }
 
 func (b *Reader) ReadInt() (int32, error) {
-	bs := make([]byte, 4)
-	_, err := b.Read(bs)
+	bs, err := b.ReadBytes(4)
 	if err != nil {
 		return 0, err
 	}
@@ -22,8 +35,7 @@ func (b *Reader) ReadInt() (int32, error) {
 }
 
 func (b *Reader) ReadLong() (int64, error) {
-	bs := make([]byte, 8)
-	_, err := b.Read(bs)
+	bs, err := b.ReadBytes(8)
 	if err != nil {
 		return 0, err
 	}
@@ -39,7 +51,9 @@ func (b *Reader) ReadBytes(n int) ([]byte, error) {
 	if n == 0 {
 		return bs, nil
 	}
-	_, err := b.Read(bs)
+	// `io.ReadFull` is used instead of `Read` since a streaming reader
+	// is allowed to return fewer bytes than requested
+	_, err := io.ReadFull(b.Reader, bs)
 	if err != nil {
 		return nil, err
 	}
@@ -3,6 +3,7 @@ package lbytes
 import (
 	"bytes"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -13,20 +14,34 @@ var tests = map[string]struct {
 }{}
 
 func TestBytesReader_ReadInt(t *testing.T) {
-	reader := Reader{
-		Reader: *bytes.NewReader(
-			[]byte{
-				3, 1, 4, 3,
-				12, 34, 56, 78,
-			},
-		),
-	}
+	reader := NewBytesReader(
+		[]byte{
+			3, 1, 4, 3,
+			12, 34, 56, 78,
+		},
+	)
 
 	resultInt1, err := reader.ReadInt()
 	assert.NoError(t, err)
-	assert.Equal(t, 50594051, resultInt1)
+	assert.Equal(t, int32(50594051), resultInt1)
 
 	resultInt2, err := reader.ReadInt()
 	assert.NoError(t, err)
-	assert.Equal(t, 1312301580, resultInt2)
+	assert.Equal(t, int32(1312301580), resultInt2)
+}
+
+func TestReader_ReadBytes_OneByteReader(t *testing.T) {
+	// a streaming source may hand out fewer bytes than requested on each read
+	reader := NewReader(
+		iotest.OneByteReader(
+			bytes.NewReader([]byte{1, 2, 3, 4, 5}),
+		),
+	)
+
+	bs, err := reader.ReadBytes(4)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3, 4}, bs)
+
+	_, err = reader.ReadBytes(4)
+	assert.Error(t, err)
 }
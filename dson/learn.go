@@ -0,0 +1,255 @@
+package dson
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/ds"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	// SchemaLearner records how the fields of many DSON files look like, grouped by their generalized hierarchy
+	// paths (see GeneralizePath), to learn the data types that cannot be inferred from the raw data alone.
+	SchemaLearner struct {
+		observationByKey map[string]*PathObservation
+		// keys keeps the order in which the paths were first seen
+		keys []string
+	}
+	// PathObservation is what was seen of the fields that share the same generalized hierarchy path.
+	PathObservation struct {
+		Path  []string
+		Count int
+		// RawLengths are the distinct lengths of the raw data (with the padded bytes stripped) in ascending order.
+		RawLengths []int
+		// DataTypes counts the data type that fits each field best.
+		DataTypes map[dfield.DataType]int
+		// Candidates are the data types that fit every field (see dfield.CandidateDataTypes).
+		Candidates []dfield.DataType
+		// RawDataTypes counts the data type that is inferred from the raw data of each field without a schema.
+		RawDataTypes map[dfield.DataType]int
+		// SchemaDataType is the data type that the schema in use gives to the fields, if any.
+		SchemaDataType dfield.DataType
+	}
+	// SchemaConflict is a path whose fields do not agree on a data type, or disagree with the schema in use.
+	SchemaConflict struct {
+		Observation PathObservation
+		Reason      string
+	}
+)
+
+func NewSchemaLearner() *SchemaLearner {
+	return &SchemaLearner{
+		observationByKey: make(map[string]*PathObservation),
+		keys:             make([]string, 0),
+	}
+}
+
+// GeneralizePath drops the root object from the hierarchy path, and replaces the keys that are numbers (which are
+// mostly IDs) with dfield.SchemaWildcard. The keys of duplicated fields become their field names.
+func GeneralizePath(hierarchyPath []string) []string {
+	return lo.Map(
+		lo.Drop(hierarchyPath, 1),
+		func(key string, _ int) string {
+			if isIDKey(key) {
+				return dfield.SchemaWildcard
+			}
+			return dfield.FromKey(key)
+		},
+	)
+}
+
+// Observe records the fields of the file, including the fields of its embedded files.
+func (l *SchemaLearner) Observe(file dstruct.Struct) {
+	for _, field := range file.Fields {
+		if field.Inferences.IsObject {
+			continue
+		}
+		if field.Inferences.DataType == dfield.DataTypeFileDecoded {
+			l.Observe(field.Inferences.Data.(dstruct.Struct))
+			continue
+		}
+		l.observe(field)
+	}
+}
+
+func (l *SchemaLearner) observe(field dfield.DataField) {
+	path := GeneralizePath(field.Inferences.HierarchyPath)
+	key := strings.Join(path, "\x00")
+	rawDataStripped := field.Inferences.RawDataStripped
+	candidates := dfield.CandidateDataTypes(rawDataStripped)
+	rawDataType := dfield.InferDataTypeByRawData(rawDataStripped)
+	schemaDataType := dfield.InferDataTypeByFieldName(field.Name)
+	if schemaDataType == dfield.DataTypeUnknown {
+		schemaDataType = dfield.InferDataTypeByHierarchyPath(lo.Drop(field.Inferences.HierarchyPath, 1))
+	}
+
+	observation, ok := l.observationByKey[key]
+	if !ok {
+		observation = &PathObservation{
+			Path:           path,
+			RawLengths:     make([]int, 0),
+			DataTypes:      make(map[dfield.DataType]int),
+			Candidates:     candidates,
+			RawDataTypes:   make(map[dfield.DataType]int),
+			SchemaDataType: schemaDataType,
+		}
+		l.observationByKey[key] = observation
+		l.keys = append(l.keys, key)
+	}
+	observation.Count += 1
+	if !lo.Contains(observation.RawLengths, len(rawDataStripped)) {
+		observation.RawLengths = append(observation.RawLengths, len(rawDataStripped))
+		sort.Ints(observation.RawLengths)
+	}
+	observation.Candidates = lo.Intersect(observation.Candidates, candidates)
+	observation.RawDataTypes[rawDataType] += 1
+
+	bestDataType := dfield.DataTypeUnknown
+	switch {
+	case lo.Contains(candidates, schemaDataType):
+		bestDataType = schemaDataType
+	case lo.Contains(candidates, rawDataType):
+		bestDataType = rawDataType
+	case len(candidates) > 0:
+		bestDataType = candidates[0]
+	}
+	observation.DataTypes[bestDataType] += 1
+}
+
+// Observations returns what was seen of each path, in the order the paths were first seen.
+func (l *SchemaLearner) Observations() []PathObservation {
+	return lo.Map(
+		l.keys,
+		func(key string, _ int) PathObservation { return *l.observationByKey[key] },
+	)
+}
+
+// LearnedDataType returns the data type that fits every field of the path best. ok is false if there is none.
+func (o PathObservation) LearnedDataType() (dataType dfield.DataType, ok bool) {
+	if len(o.Candidates) == 0 {
+		return dfield.DataTypeUnknown, false
+	}
+	if lo.Contains(o.Candidates, o.SchemaDataType) {
+		return o.SchemaDataType, true
+	}
+	// the data type that is inferred without a schema is kept if it fits, since no rule is needed then
+	rawDataTypes := lo.Keys(o.RawDataTypes)
+	if len(rawDataTypes) == 1 && lo.Contains(o.Candidates, rawDataTypes[0]) {
+		return rawDataTypes[0], true
+	}
+	return o.Candidates[0], true
+}
+
+// NeedsRule tells whether the learned data type cannot be inferred from the raw data without a schema.
+func (o PathObservation) NeedsRule() bool {
+	dataType, ok := o.LearnedDataType()
+	if !ok {
+		return false
+	}
+	return len(o.RawDataTypes) != 1 || o.RawDataTypes[dataType] == 0
+}
+
+// Conflicts returns the paths whose fields do not fit a common data type, or do not fit the data type that is given
+// by the schema in use.
+func (l *SchemaLearner) Conflicts() []SchemaConflict {
+	conflicts := make([]SchemaConflict, 0)
+	for _, observation := range l.Observations() {
+		dataType, ok := observation.LearnedDataType()
+		switch {
+		case !ok:
+			conflicts = append(conflicts, SchemaConflict{
+				Observation: observation,
+				Reason:      "seen as " + formatDataTypeCounts(observation.DataTypes),
+			})
+		case observation.SchemaDataType != dfield.DataTypeUnknown && dataType != observation.SchemaDataType:
+			conflicts = append(conflicts, SchemaConflict{
+				Observation: observation,
+				Reason: fmt.Sprintf(
+					`the schema gives "%s", but seen as %s`,
+					observation.SchemaDataType, formatDataTypeCounts(observation.DataTypes),
+				),
+			})
+		}
+	}
+	return conflicts
+}
+
+func formatDataTypeCounts(dataTypeCounts map[dfield.DataType]int) string {
+	dataTypes := lo.Keys(dataTypeCounts)
+	sort.Slice(
+		dataTypes,
+		func(i, j int) bool {
+			countI := dataTypeCounts[dataTypes[i]]
+			countJ := dataTypeCounts[dataTypes[j]]
+			return countI > countJ || countI == countJ && dataTypes[i] < dataTypes[j]
+		},
+	)
+	return strings.Join(
+		lo.Map(
+			dataTypes,
+			func(dataType dfield.DataType, _ int) string {
+				return fmt.Sprintf("%s (%d)", dataType, dataTypeCounts[dataType])
+			},
+		),
+		", ",
+	)
+}
+
+func (c SchemaConflict) String() string {
+	rawLengths := lo.Map(c.Observation.RawLengths, func(rawLength int, _ int) string { return fmt.Sprint(rawLength) })
+	return fmt.Sprintf(
+		"%s: %s; raw lengths: %s",
+		strings.Join(c.Observation.Path, "."), c.Reason, strings.Join(rawLengths, ", "),
+	)
+}
+
+// Schema returns the schema in use, together with the learned rules for the paths that need them. A rule is
+// learned by field name if every path that ends with the field name agrees on the data type, or by path otherwise.
+func (l *SchemaLearner) Schema() dfield.Schema {
+	currentSchema := dfield.CurrentSchema()
+	learnedSchema := dfield.Schema{
+		FieldNames:           make(map[string]dfield.DataType),
+		HierarchyPaths:       ds.ShallowCopy(currentSchema.HierarchyPaths),
+		UnhashFieldNames:     currentSchema.UnhashFieldNames,
+		UnhashHierarchyPaths: currentSchema.UnhashHierarchyPaths,
+	}
+	for fieldName, dataType := range currentSchema.FieldNames {
+		learnedSchema.FieldNames[fieldName] = dataType
+	}
+
+	observations := l.Observations()
+	observationsByFieldName := lo.GroupBy(
+		observations,
+		func(observation PathObservation) string { fieldName, _ := lo.Last(observation.Path); return fieldName },
+	)
+	for _, observation := range observations {
+		dataType, ok := observation.LearnedDataType()
+		if !ok || !observation.NeedsRule() || dataType == observation.SchemaDataType {
+			continue
+		}
+		fieldName, _ := lo.Last(observation.Path)
+		agreed := fieldName != dfield.SchemaWildcard && lo.EveryBy(
+			observationsByFieldName[fieldName],
+			func(other PathObservation) bool {
+				otherDataType, ok := other.LearnedDataType()
+				return ok && otherDataType == dataType
+			},
+		)
+		if agreed {
+			learnedSchema.FieldNames[fieldName] = dataType
+			continue
+		}
+		learnedSchema.HierarchyPaths = append(
+			learnedSchema.HierarchyPaths,
+			dfield.HierarchyPathRule{
+				Path:     observation.Path,
+				DataType: dataType,
+			},
+		)
+	}
+	return learnedSchema
+}
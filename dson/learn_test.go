@@ -0,0 +1,96 @@
+package dson
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func TestGeneralizePath(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{"heroes", "*", "hero_file_data", "raw_data"},
+		GeneralizePath([]string{"base_root", "heroes", "56", "hero_file_data", "raw_data"}),
+	)
+	assert.Equal(
+		t,
+		[]string{"real_achievements", "slay_a_squiffy_with_jester"},
+		GeneralizePath([]string{"base_root", "real_achievements", "slay_a_squiffy_with_jester__duplicated_dont_touch_2"}),
+	)
+}
+
+func TestSchemaLearner_FromScratch(t *testing.T) {
+	dfield.UseSchema(dfield.Schema{})
+	defer dfield.UseSchema(dfield.DefaultSchema())
+
+	filePaths, err := filepath.Glob("../sample_dson/*.json")
+	require.NoError(t, err)
+	learner := NewSchemaLearner()
+	for _, filePath := range filePaths {
+		fileBytes, err := ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+		file, err := dstruct.ToStructuredFileLeniently(fileBytes)
+		require.NoError(t, err, filePath)
+		learner.Observe(*file)
+	}
+	require.Empty(t, learner.Conflicts())
+
+	schema := learner.Schema()
+	assert.Equal(t, dfield.DataTypeFloat, schema.FieldNames["current_hp"])
+	assert.Equal(t, dfield.DataTypeIntVector, schema.FieldNames["dungeon_history"])
+	assert.Equal(t, dfield.DataTypeStringVector, schema.FieldNames["goal_ids"])
+
+	// the learned schema is enough to decode and encode the samples back
+	dfield.UseSchema(schema)
+	for _, filePath := range filePaths {
+		fileBytes, err := ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+		jsonBytes, annotationsBytes, err := DecodeDSONAnnotated(fileBytes, false)
+		require.NoError(t, err, filePath)
+		encodedBytes, err := EncodeJSONAnnotated(jsonBytes, annotationsBytes)
+		require.NoError(t, err, filePath)
+		require.Equal(t, fileBytes, encodedBytes, filePath)
+	}
+}
+
+func TestSchemaLearner_Conflicts(t *testing.T) {
+	createField := func(hierarchyPath []string, rawDataStripped []byte) dfield.DataField {
+		return dfield.DataField{
+			Name: hierarchyPath[len(hierarchyPath)-1],
+			Inferences: dfield.Inferences{
+				HierarchyPath:   hierarchyPath,
+				RawDataStripped: rawDataStripped,
+				DataType:        dfield.DataTypeInt,
+			},
+		}
+	}
+	file := dstruct.Struct{
+		Fields: []dfield.DataField{
+			createField([]string{"base_root", "heroes", "1", "level"}, dfield.EncodeValueInt(3)),
+			createField([]string{"base_root", "heroes", "2", "level"}, dfield.EncodeValueFloat(2.5)),
+			createField([]string{"base_root", "heroes", "1", "current_hp"}, dfield.EncodeValueInt(12)),
+			createField([]string{"base_root", "heroes", "1", "gold"}, dfield.EncodeValueFloat(0.5)),
+		},
+	}
+	learner := NewSchemaLearner()
+	learner.Observe(file)
+
+	conflicts := lo.Map(learner.Conflicts(), func(conflict SchemaConflict, _ int) string { return conflict.String() })
+	require.Equal(
+		t,
+		[]string{
+			"heroes.*.level: seen as float (1), int (1); raw lengths: 4",
+			`heroes.*.current_hp: the schema gives "float", but seen as int (1); raw lengths: 4`,
+		},
+		conflicts,
+	)
+	schema := learner.Schema()
+	require.Equal(t, dfield.DataTypeFloat, schema.FieldNames["gold"])
+	require.NotContains(t, schema.FieldNames, "level")
+}
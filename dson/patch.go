@@ -0,0 +1,34 @@
+package dson
+
+import (
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dpatch"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// Patch applies a JSON Patch (RFC 6902) document to the ordered map of the file, and encodes the result back.
+// The paths are JSON Pointers within the converted JSON file, such as `/base_root/wallet/0/amount`; embedded files
+// are treated as normal objects.
+func Patch(file dstruct.Struct, patchBytes []byte) (*dstruct.Struct, error) {
+	operations, err := dpatch.DecodeOperations(patchBytes)
+	if err != nil {
+		err := errors.Wrap(err, "dson.Patch error")
+		return nil, err
+	}
+	node, err := dstruct.ToJSONNode(file)
+	if err != nil {
+		err := errors.Wrap(err, "dson.Patch error")
+		return nil, err
+	}
+	patchedNode, err := dpatch.Apply(*node, operations)
+	if err != nil {
+		err := errors.Wrap(err, "dson.Patch error")
+		return nil, err
+	}
+	patchedFile, err := dstruct.FromJSONNode(patchedNode, &file)
+	if err != nil {
+		err := errors.Wrap(err, "dson.Patch error")
+		return nil, err
+	}
+	return patchedFile, nil
+}
@@ -0,0 +1,32 @@
+package dson
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func TestPatch(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.estate.json")
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+
+	patchedFile, err := Patch(*file, []byte(`[]`))
+	require.NoError(t, err)
+	require.Equal(t, fileBytes, dstruct.EncodeStruct(*patchedFile))
+
+	patchedFile, err = Patch(*file, []byte(`[{"op":"replace","path":"/base_root/wallet/0/amount","value":50000}]`))
+	require.NoError(t, err)
+	patchedFile, err = dstruct.ToStructuredFile(dstruct.EncodeStruct(*patchedFile))
+	require.NoError(t, err)
+	differences := Diff(*file, *patchedFile)
+	require.Len(t, differences, 1)
+	require.Equal(t, []string{"base_root", "wallet", "0", "amount"}, differences[0].Path)
+	require.Equal(t, int32(50000), differences[0].New)
+
+	_, err = Patch(*file, []byte(`[{"op":"remove","path":"/base_root/missing"}]`))
+	require.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package dson
+
+import (
+	"strings"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// QueryWildcard matches any single field name within a query path.
+const QueryWildcard = "*"
+
+// Query returns the fields (in their order within the file) whose full path matches the dot-separated path
+// expression, such as `base_root.heroes.*.hero_file_data.raw_data.base_root.actor.name`. Embedded files are
+// treated as normal objects. Field names that contain dots themselves (like `roster.status`) are matched as well.
+func Query(file dstruct.Struct, path string) []FlatField {
+	tokens := strings.Split(path, ".")
+	matchedFields := make([]FlatField, 0)
+	for _, flatField := range Flatten(file) {
+		if MatchPath(tokens, flatField.Path) {
+			matchedFields = append(matchedFields, flatField)
+		}
+	}
+	return matchedFields
+}
+
+func MatchPath(tokens []string, path []string) bool {
+	if len(path) == 0 {
+		return len(tokens) == 0
+	}
+	if len(tokens) == 0 {
+		return false
+	}
+	if tokens[0] == QueryWildcard {
+		return MatchPath(tokens[1:], path[1:])
+	}
+	name := path[0]
+	joinedTokens := ""
+	for i, token := range tokens {
+		if i > 0 {
+			joinedTokens += "."
+		}
+		joinedTokens += token
+		if joinedTokens == name {
+			return MatchPath(tokens[i+1:], path[1:])
+		}
+		if len(joinedTokens) >= len(name) {
+			return false
+		}
+	}
+	return false
+}
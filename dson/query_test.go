@@ -0,0 +1,51 @@
+package dson
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func TestQuery(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.roster.json")
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+
+	names := Query(*file, "base_root.heroes.*.hero_file_data.raw_data.base_root.actor.name")
+	require.Len(t, names, 20)
+	assert.Equal(t, "Botin", names[0].Field.Inferences.Data)
+
+	statuses := Query(*file, "base_root.heroes.56.hero_file_data.raw_data.base_root.roster.status")
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "roster.status", statuses[0].Field.Name)
+
+	assert.Empty(t, Query(*file, "base_root.heroes.*.actor.name"))
+	assert.Empty(t, Query(*file, "base_root.heroes.56.hero_file_data.raw_data.base_root.roster"))
+}
+
+func TestMatchPath(t *testing.T) {
+	type testCase struct {
+		Tokens   []string
+		Path     []string
+		Expected bool
+	}
+	testCases := []testCase{
+		{[]string{"a", "b"}, []string{"a", "b"}, true},
+		{[]string{"a", "*"}, []string{"a", "b.c"}, true},
+		{[]string{"a", "b", "c"}, []string{"a", "b.c"}, true},
+		{[]string{"a", "b"}, []string{"a", "b.c"}, false},
+		{[]string{"a", "b", "c", "d"}, []string{"a", "b.c"}, false},
+		{[]string{"*"}, []string{"a", "b"}, false},
+	}
+	lo.ForEach(
+		testCases,
+		func(c testCase, _ int) {
+			assert.Equal(t, c.Expected, MatchPath(c.Tokens, c.Path), c)
+		},
+	)
+}
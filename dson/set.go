@@ -0,0 +1,45 @@
+package dson
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// Set replaces the data of every field that matches the path expression (see `Query`) with the parsed text, and
+// returns the updated file together with the updated fields. Each field keeps its original data type; the text is
+// parsed according to it, and vectors are written as JSON arrays.
+func Set(file dstruct.Struct, path string, text string) (*dstruct.Struct, []FlatField, error) {
+	flatFields := Query(file, path)
+	if len(flatFields) == 0 {
+		err := fmt.Errorf(`dson.Set error: no field matched path "%s"`, path)
+		return nil, nil, err
+	}
+
+	updatedFile := &file
+	updatedFlatFields := make([]FlatField, 0, len(flatFields))
+	for _, flatField := range flatFields {
+		if flatField.IsContainer() {
+			err := fmt.Errorf(`dson.Set error: field "%s" is not a value`, flatField.PathString())
+			return nil, nil, err
+		}
+		err := error(nil)
+		updatedFile, err = dstruct.UpdateField(
+			*updatedFile,
+			flatField.Indexes,
+			func(field dfield.DataField) (dfield.DataField, error) {
+				field, err := dfield.SetDataByText(field, text)
+				flatField.Field = field
+				return field, err
+			},
+		)
+		if err != nil {
+			err := errors.Wrap(err, "dson.Set error")
+			return nil, nil, err
+		}
+		updatedFlatFields = append(updatedFlatFields, flatField)
+	}
+	return updatedFile, updatedFlatFields, nil
+}
@@ -0,0 +1,60 @@
+package dson
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func TestSet(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.roster.json")
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+
+	heroPath := "base_root.heroes.56.hero_file_data.raw_data.base_root."
+	updatedFile, _, err := Set(*file, heroPath+"actor.name", "Botin the Unbreakable")
+	require.NoError(t, err)
+	updatedFile, _, err = Set(*updatedFile, heroPath+"actor.current_hp", "30")
+	require.NoError(t, err)
+	updatedFile, updatedFlatFields, err := Set(*updatedFile, "base_root.heroes.*.hero_file_data.raw_data.base_root.m_Stress", "0")
+	require.NoError(t, err)
+	require.Len(t, updatedFlatFields, 20)
+
+	// the file is decoded again to make sure the offsets and lengths were recalculated correctly
+	updatedFile, err = dstruct.ToStructuredFile(dstruct.EncodeStruct(*updatedFile))
+	require.NoError(t, err)
+	names := Query(*updatedFile, heroPath+"actor.name")
+	require.Equal(t, "Botin the Unbreakable", names[0].Field.Inferences.Data)
+	hps := Query(*updatedFile, heroPath+"actor.current_hp")
+	require.Equal(t, dfield.DataTypeFloat, hps[0].Field.Inferences.DataType)
+	require.Equal(t, float32(30), hps[0].Field.Inferences.Data)
+
+	for _, difference := range Diff(*file, *updatedFile) {
+		require.Equal(t, DifferenceKindChanged, difference.Kind)
+		require.Equal(t, difference.OldType, difference.NewType)
+	}
+
+	// setting the same values back gives the original file
+	restoredFile, _, err := Set(*updatedFile, heroPath+"actor.name", "Botin")
+	require.NoError(t, err)
+	restoredFile, _, err = Set(*restoredFile, heroPath+"actor.current_hp", "37")
+	require.NoError(t, err)
+	for _, flatField := range Query(*file, "base_root.heroes.*.hero_file_data.raw_data.base_root.m_Stress") {
+		restoredFile, err = dstruct.UpdateField(
+			*restoredFile,
+			flatField.Indexes,
+			func(dfield.DataField) (dfield.DataField, error) { return flatField.Field, nil },
+		)
+		require.NoError(t, err)
+	}
+	require.Equal(t, fileBytes, dstruct.EncodeStruct(*restoredFile))
+
+	_, _, err = Set(*file, heroPath+"actor", "1")
+	require.Error(t, err)
+	_, _, err = Set(*file, heroPath+"missing", "1")
+	require.Error(t, err)
+}
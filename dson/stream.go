@@ -0,0 +1,193 @@
+package dson
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/dson/lbytes"
+)
+
+type (
+	// Format is the text format that DSON files are converted to, and converted from.
+	Format string
+	// Decoder reads a DSON file from an input stream, in the same spirit as `json.Decoder`.
+	Decoder struct {
+		reader            *lbytes.Reader
+		debug             bool
+		format            Format
+		annotationsWriter io.Writer
+	}
+	// Encoder writes a DSON file to an output stream, in the same spirit as `json.Encoder`.
+	Encoder struct {
+		writer      io.Writer
+		format      Format
+		annotations dstruct.Annotations
+	}
+)
+
+const (
+	FormatJSON = Format("json")
+	FormatYAML = Format("yaml")
+	// FormatJSON5 is JSON with a comment on the data type of each value. Also see: MarshalJSON5.
+	FormatJSON5 = Format("json5")
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatJSON, FormatYAML, FormatJSON5}
+
+func marshal(format Format, value any) ([]byte, error) {
+	if format == FormatYAML {
+		return MarshalYAML(value)
+	}
+	return json.MarshalIndent(value, "", "  ")
+}
+
+func unmarshal(format Format, bs []byte) (*orderedmap.OrderedMap, error) {
+	switch format {
+	case FormatYAML:
+		return UnmarshalYAML(bs)
+	case FormatJSON5:
+		return UnmarshalJSON5(bs)
+	}
+	lhm := orderedmap.New()
+	if err := json.Unmarshal(bs, lhm); err != nil {
+		return nil, err
+	}
+	return lhm, nil
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{
+		reader: lbytes.NewReader(r),
+		debug:  false,
+		format: FormatJSON,
+	}
+}
+
+// SetDebug makes DecodeJSON write the whole decoded structure (header, meta blocks, fields, and their inferences)
+// instead of only the data.
+func (d *Decoder) SetDebug(debug bool) {
+	d.debug = debug
+}
+
+// SetFormat makes DecodeJSON write the file as the format (JSON by default) instead.
+func (d *Decoder) SetFormat(format Format) {
+	d.format = format
+}
+
+// SetAnnotationsWriter makes DecodeJSON also write the annotations of the file (see `dstruct.Annotate`) to w,
+// which can be given back to the encoder later to get the exact original bytes.
+func (d *Decoder) SetAnnotationsWriter(w io.Writer) {
+	d.annotationsWriter = w
+}
+
+func (d *Decoder) Decode() (*dstruct.Struct, error) {
+	decodedFile, err := dstruct.DecodeStruct(d.reader)
+	if err != nil {
+		err := errors.Wrap(err, "Decoder.Decode error")
+		return nil, err
+	}
+	return decodedFile, nil
+}
+
+// DecodeJSON reads the DSON file and writes it to w as indented JSON, or as another format (see SetFormat).
+func (d *Decoder) DecodeJSON(w io.Writer) error {
+	decodedFile, err := d.Decode()
+	if err != nil {
+		return err
+	}
+
+	var decoded any = dstruct.ToLinkedHashMap(*decodedFile)
+	if d.debug {
+		decoded = decodedFile
+	}
+	decodedBytes := []byte(nil)
+	if d.format == FormatJSON5 && !d.debug {
+		// the data types are not kept within the ordered map
+		decodedBytes, err = MarshalJSON5(*decodedFile)
+	} else {
+		decodedBytes, err = marshal(d.format, decoded)
+	}
+	if err != nil {
+		err := errors.Wrap(err, "Decoder.DecodeJSON error marshalling")
+		return err
+	}
+	if _, err := w.Write(decodedBytes); err != nil {
+		err := errors.Wrap(err, "Decoder.DecodeJSON error writing")
+		return err
+	}
+
+	if d.annotationsWriter == nil {
+		return nil
+	}
+	annotationsBytes, err := json.MarshalIndent(dstruct.Annotate(*decodedFile), "", "  ")
+	if err != nil {
+		err := errors.Wrap(err, "Decoder.DecodeJSON error marshalling annotations")
+		return err
+	}
+	if _, err := d.annotationsWriter.Write(annotationsBytes); err != nil {
+		err := errors.Wrap(err, "Decoder.DecodeJSON error writing annotations")
+		return err
+	}
+	return nil
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{
+		writer: w,
+		format: FormatJSON,
+	}
+}
+
+// SetFormat makes EncodeJSON read the format (JSON by default) instead.
+func (e *Encoder) SetFormat(format Format) {
+	e.format = format
+}
+
+// SetAnnotations makes EncodeJSON use the annotations instead of guessing the data types of the fields.
+func (e *Encoder) SetAnnotations(annotations dstruct.Annotations) {
+	e.annotations = annotations
+}
+
+func (e *Encoder) Encode(file dstruct.Struct) error {
+	if err := dstruct.WriteStruct(e.writer, file); err != nil {
+		err := errors.Wrap(err, "Encoder.Encode error")
+		return err
+	}
+	return nil
+}
+
+// EncodeJSON reads a JSON document (or a document of another format, see SetFormat) from r and writes it as a DSON
+// file.
+func (e *Encoder) EncodeJSON(r io.Reader) error {
+	// the ordered map needs the whole document to keep track of the keys' order
+	fileBytes, err := ioutil.ReadAll(r)
+	if err != nil {
+		err := errors.Wrap(err, "Encoder.EncodeJSON error reading")
+		return err
+	}
+	lhm, err := unmarshal(e.format, fileBytes)
+	if err != nil {
+		err := errors.Wrap(err, "Encoder.EncodeJSON error unmarshalling")
+		return err
+	}
+	dsonStruct, err := dstruct.FromAnnotatedLinkedHashMap(*lhm, e.annotations)
+	if err != nil {
+		return err
+	}
+	return e.Encode(*dsonStruct)
+}
+
+// ReadAnnotations reads the annotations that were written by a decoder.
+func ReadAnnotations(r io.Reader) (dstruct.Annotations, error) {
+	annotations := dstruct.Annotations{}
+	if err := json.NewDecoder(r).Decode(&annotations); err != nil {
+		err := errors.Wrap(err, "dson.ReadAnnotations error")
+		return nil, err
+	}
+	return annotations, nil
+}
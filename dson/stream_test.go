@@ -0,0 +1,46 @@
+package dson
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecoderEncoder_RoundTrip(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.roster.json")
+	require.NoError(t, err)
+
+	// reading one byte at a time makes sure that nothing relies on the source being a whole byte slice
+	decoder := NewDecoder(iotest.OneByteReader(bytes.NewReader(fileBytes)))
+	decodedFile, err := decoder.Decode()
+	require.NoError(t, err)
+
+	buffer := bytes.Buffer{}
+	err = NewEncoder(&buffer).Encode(*decodedFile)
+	require.NoError(t, err)
+	require.Equal(t, fileBytes, buffer.Bytes())
+}
+
+func TestDecoderEncoder_JSON(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.estate.json")
+	require.NoError(t, err)
+
+	jsonBuffer := bytes.Buffer{}
+	err = NewDecoder(bytes.NewReader(fileBytes)).DecodeJSON(&jsonBuffer)
+	require.NoError(t, err)
+
+	expectedJSONBytes, err := DecodeDSON(fileBytes, false)
+	require.NoError(t, err)
+	require.Equal(t, expectedJSONBytes, jsonBuffer.Bytes())
+
+	dsonBuffer := bytes.Buffer{}
+	err = NewEncoder(&dsonBuffer).EncodeJSON(&jsonBuffer)
+	require.NoError(t, err)
+
+	expectedDSONBytes, err := EncodeJSON(expectedJSONBytes)
+	require.NoError(t, err)
+	require.Equal(t, expectedDSONBytes, dsonBuffer.Bytes())
+}
@@ -0,0 +1,101 @@
+package dson
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dhash"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	// UnresolvedHash is an integer that is not found within the names (see dhash.NameByHash), while other integers of
+	// the same path are, which means it is most likely the hash of an unknown name, like a skill or a trinket of a mod.
+	UnresolvedHash struct {
+		Path  []string
+		Value int32
+	}
+	// AmbiguousHash is a hash that more than one known name hashes to (see dhash.CollisionsByHash), so the unhashed
+	// name of the value might not be the right one.
+	AmbiguousHash struct {
+		Path  []string
+		Value int32
+		Names []string
+	}
+)
+
+// integers returns the integers that a field of data type int, int_vector, or hybrid_vector holds.
+func integers(field dfield.DataField) []int32 {
+	switch data := field.Inferences.Data.(type) {
+	case int32:
+		return []int32{data}
+	case []int32:
+		return data
+	case []any:
+		values := make([]int32, 0, len(data))
+		for _, item := range data {
+			if value, ok := item.(int32); ok {
+				values = append(values, value)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// FindUnresolvedHashes lists the integers of the file (including its embedded files) that are most likely hashes,
+// but cannot be unhashed with the known names. Paths are generalized (see GeneralizePath) to learn which of them hold
+// hashes, so an integer is reported if the same field, or a field of the same path, has any unhashed name.
+func FindUnresolvedHashes(file dstruct.Struct) []UnresolvedHash {
+	flatFields := Flatten(file)
+	generalize := func(flatField FlatField) string {
+		return strings.Join(GeneralizePath(flatField.Path), "\x00")
+	}
+	unhashedPaths := make(map[string]bool)
+	for _, flatField := range flatFields {
+		if !flatField.IsContainer() && len(dfield.UnhashedValues(flatField.Field)) > 0 {
+			unhashedPaths[generalize(flatField)] = true
+		}
+	}
+
+	unresolvedHashes := make([]UnresolvedHash, 0)
+	for _, flatField := range flatFields {
+		if flatField.IsContainer() || !unhashedPaths[generalize(flatField)] {
+			continue
+		}
+		for _, value := range integers(flatField.Field) {
+			// zero is the hash of an empty name, which means "none"
+			if value == 0 {
+				continue
+			}
+			unresolvedHashes = append(unresolvedHashes, UnresolvedHash{Path: flatField.Path, Value: value})
+		}
+	}
+	return unresolvedHashes
+}
+
+func (r UnresolvedHash) String() string {
+	return strings.Join(r.Path, ".") + ": " + fmt.Sprint(r.Value)
+}
+
+// FindAmbiguousHashes lists the unhashed values of the file (including its embedded files) whose hashes are shared by
+// more than one known name.
+func FindAmbiguousHashes(file dstruct.Struct) []AmbiguousHash {
+	ambiguousHashes := make([]AmbiguousHash, 0)
+	for _, flatField := range Flatten(file) {
+		if flatField.IsContainer() {
+			continue
+		}
+		for _, value := range dfield.UnhashedValues(flatField.Field) {
+			if names, ok := dhash.CollisionsByHash[value]; ok {
+				ambiguousHashes = append(ambiguousHashes, AmbiguousHash{Path: flatField.Path, Value: value, Names: names})
+			}
+		}
+	}
+	return ambiguousHashes
+}
+
+func (r AmbiguousHash) String() string {
+	return fmt.Sprintf("%s: %d is the hash of %s", strings.Join(r.Path, "."), r.Value, strings.Join(r.Names, ", "))
+}
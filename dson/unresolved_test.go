@@ -0,0 +1,52 @@
+package dson
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dhash"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func TestFindUnresolvedHashes(t *testing.T) {
+	file := readStruct(t, "../sample_dson/persist.tutorial.json")
+	// the other events are unhashed, so the remaining integer is most likely the hash of an unknown name
+	assert.Equal(
+		t,
+		[]UnresolvedHash{{Path: []string{"base_root", "dispatched_events"}, Value: 1972053455}},
+		FindUnresolvedHashes(*file),
+	)
+
+	dhash.NameByHash[1972053455] = "###unknown_event"
+	defer delete(dhash.NameByHash, 1972053455)
+	file = readStruct(t, "../sample_dson/persist.tutorial.json")
+	assert.Empty(t, FindUnresolvedHashes(*file))
+}
+
+func TestFindAmbiguousHashes(t *testing.T) {
+	hash := dhash.HashString("plot_tutorial_crypts")
+	// the last two bytes are hashed to the same value: 't' * 53 + 's' == 'u' * 53 + '>'
+	dhash.AddNames([]string{"plot_tutorial_crypu>"})
+	defer delete(dhash.CollisionsByHash, hash)
+
+	file := readStruct(t, "../sample_dson/persist.campaign_log.json")
+	ambiguousHashes := FindAmbiguousHashes(*file)
+	require.Len(t, ambiguousHashes, 2)
+	names := []string{"###plot_tutorial_crypts", "###plot_tutorial_crypu>"}
+	assert.Equal(
+		t,
+		AmbiguousHash{Path: []string{"base_root", "chapters", "1", "5", "quest_id"}, Value: hash, Names: names},
+		ambiguousHashes[0],
+	)
+
+	questIDs := Query(*file, "base_root.chapters.1.5.quest_id")
+	assert.Equal(t, "###plot_tutorial_crypts", questIDs[0].Field.Inferences.Data)
+	assert.Equal(
+		t,
+		"string (hash: -378081950) (ambiguous: ###plot_tutorial_crypts, ###plot_tutorial_crypu>)",
+		DescribeDataType(questIDs[0].Field),
+	)
+	annotations := dstruct.Annotate(*file)
+	assert.Equal(t, names, annotations["/base_root/chapters/1/5/quest_id"].AmbiguousNames)
+}
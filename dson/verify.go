@@ -0,0 +1,264 @@
+package dson
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/ds"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	// Divergence is the first difference between a section of the original file and the same section of the
+	// re-encoded file. Path is the hierarchy path of the related field, which goes through the embedded files.
+	Divergence struct {
+		Section Section
+		Index   int
+		Path    []string
+		Detail  string
+	}
+	Section string
+)
+
+const (
+	SectionHeader     = Section("header")
+	SectionMeta1Block = Section("meta 1 block")
+	SectionMeta2Block = Section("meta 2 block")
+	SectionData       = Section("data")
+	// SectionBytes is the whole encoded file, for divergences that are not within the other sections
+	SectionBytes = Section("bytes")
+)
+
+// Verify decodes the original bytes of a file, encodes it again the same way a converted JSON file is encoded (with
+// annotations if they are given), and compares the encoded bytes with the original ones. An empty result means the
+// round trip gives the exact original bytes. Otherwise, the first divergence of each section is returned, or the
+// first different byte if the sections do not tell where the bytes diverge (like extra bytes at the end).
+func Verify(originalBytes []byte, annotations dstruct.Annotations) ([]Divergence, error) {
+	file, err := dstruct.ToStructuredFile(originalBytes)
+	if err != nil {
+		err := errors.Wrap(err, "dson.Verify error decoding")
+		return nil, err
+	}
+	node, err := dstruct.ToJSONNode(*file)
+	if err != nil {
+		err := errors.Wrap(err, "dson.Verify error")
+		return nil, err
+	}
+	encodedFile := (*dstruct.Struct)(nil)
+	if annotations != nil {
+		encodedFile, err = dstruct.FromAnnotatedLinkedHashMap(*node, annotations)
+	} else {
+		encodedFile, err = dstruct.FromLinkedHashMap(*node)
+	}
+	if err != nil {
+		err := errors.Wrap(err, "dson.Verify error")
+		return nil, err
+	}
+	encodedBytes := dstruct.EncodeStruct(*encodedFile)
+	if bytes.Equal(originalBytes, encodedBytes) {
+		return []Divergence{}, nil
+	}
+	divergences := CompareStructs(*file, *encodedFile)
+	if len(divergences) == 0 {
+		divergences = append(divergences, CompareBytes(originalBytes, encodedBytes))
+	}
+	return divergences, nil
+}
+
+// CompareBytes returns the first different byte between the two (different) encoded files.
+func CompareBytes(expected []byte, actual []byte) Divergence {
+	for i := 0; i < len(expected) && i < len(actual); i++ {
+		if expected[i] != actual[i] {
+			return Divergence{
+				Section: SectionBytes,
+				Index:   i,
+				Path:    []string{},
+				Detail:  fmt.Sprintf(`expected byte "%02x"; got "%02x"`, expected[i], actual[i]),
+			}
+		}
+	}
+	return Divergence{
+		Section: SectionBytes,
+		Index:   lo.Min([]int{len(expected), len(actual)}),
+		Path:    []string{},
+		Detail:  fmt.Sprintf(`expected "%d" bytes; got "%d"`, len(expected), len(actual)),
+	}
+}
+
+// CompareStructs returns the first divergence of each section between the two files. A divergence within an embedded
+// file is reported with the sections and the fields of the embedded file.
+func CompareStructs(expected dstruct.Struct, actual dstruct.Struct) []Divergence {
+	return compareStructs([]string{}, expected, actual)
+}
+
+func compareStructs(parentPath []string, expected dstruct.Struct, actual dstruct.Struct) []Divergence {
+	divergences := make([]Divergence, 0)
+	pathOf := func(index int) []string {
+		if index < len(expected.Fields) {
+			return append(ds.ShallowCopy(parentPath), expected.Fields[index].Inferences.HierarchyPath...)
+		}
+		return parentPath
+	}
+
+	if name, expectedValue, actualValue, ok := firstDifferentField(expected.Header, actual.Header); ok {
+		divergences = append(
+			divergences,
+			Divergence{
+				Section: SectionHeader,
+				Index:   -1,
+				Path:    parentPath,
+				Detail:  fmt.Sprintf(`expected %s "%v"; got "%v"`, name, expectedValue, actualValue),
+			},
+		)
+	}
+
+	for i := 0; i < len(expected.Meta1Block) || i < len(actual.Meta1Block); i++ {
+		if i >= len(expected.Meta1Block) || i >= len(actual.Meta1Block) {
+			divergences = append(
+				divergences,
+				Divergence{
+					Section: SectionMeta1Block,
+					Index:   i,
+					Path:    parentPath,
+					Detail:  fmt.Sprintf(`expected "%d" entries; got "%d"`, len(expected.Meta1Block), len(actual.Meta1Block)),
+				},
+			)
+			break
+		}
+		if name, expectedValue, actualValue, ok := firstDifferentField(expected.Meta1Block[i], actual.Meta1Block[i]); ok {
+			divergences = append(
+				divergences,
+				Divergence{
+					Section: SectionMeta1Block,
+					Index:   i,
+					Path:    pathOf(int(expected.Meta1Block[i].Meta2EntryIndex)),
+					Detail:  fmt.Sprintf(`expected %s "%v"; got "%v"`, name, expectedValue, actualValue),
+				},
+			)
+			break
+		}
+	}
+
+	for i := 0; i < len(expected.Meta2Block) || i < len(actual.Meta2Block); i++ {
+		if i >= len(expected.Meta2Block) || i >= len(actual.Meta2Block) {
+			divergences = append(
+				divergences,
+				Divergence{
+					Section: SectionMeta2Block,
+					Index:   i,
+					Path:    pathOf(i),
+					Detail:  fmt.Sprintf(`expected "%d" entries; got "%d"`, len(expected.Meta2Block), len(actual.Meta2Block)),
+				},
+			)
+			break
+		}
+		if name, expectedValue, actualValue, ok := firstDifferentField(expected.Meta2Block[i], actual.Meta2Block[i]); ok {
+			divergences = append(
+				divergences,
+				Divergence{
+					Section: SectionMeta2Block,
+					Index:   i,
+					Path:    pathOf(i),
+					Detail:  fmt.Sprintf(`expected %s "%v"; got "%v"`, name, expectedValue, actualValue),
+				},
+			)
+			break
+		}
+	}
+
+	for i := 0; i < len(expected.Fields) || i < len(actual.Fields); i++ {
+		if i >= len(expected.Fields) || i >= len(actual.Fields) {
+			divergences = append(
+				divergences,
+				Divergence{
+					Section: SectionData,
+					Index:   i,
+					Path:    pathOf(i),
+					Detail:  fmt.Sprintf(`expected "%d" fields; got "%d"`, len(expected.Fields), len(actual.Fields)),
+				},
+			)
+			break
+		}
+		expectedField := expected.Fields[i]
+		actualField := actual.Fields[i]
+		if expectedField.Name == actualField.Name && bytes.Equal(expectedField.RawData, actualField.RawData) {
+			continue
+		}
+
+		expectedEmbedded, ok1 := expectedField.Inferences.Data.(dstruct.Struct)
+		actualEmbedded, ok2 := actualField.Inferences.Data.(dstruct.Struct)
+		if ok1 && ok2 && expectedField.Name == actualField.Name {
+			embeddedDivergences := compareStructs(pathOf(i), expectedEmbedded, actualEmbedded)
+			if len(embeddedDivergences) > 0 {
+				divergences = append(divergences, embeddedDivergences...)
+				break
+			}
+		}
+
+		detail := ""
+		expectedInferences := expectedField.Inferences
+		actualInferences := actualField.Inferences
+		switch true {
+		case expectedField.Name != actualField.Name:
+			detail = fmt.Sprintf(`expected name "%s"; got "%s"`, expectedField.Name, actualField.Name)
+		case !bytes.Equal(expectedInferences.RawDataStripped, actualInferences.RawDataStripped):
+			detail = fmt.Sprintf(
+				`expected (%s) %s; got (%s) %s`,
+				expectedInferences.DataType, FormatValue(expectedInferences.DataType, expectedInferences.Data),
+				actualInferences.DataType, FormatValue(actualInferences.DataType, actualInferences.Data),
+			)
+		default:
+			expectedPaddedBytes := expectedField.RawData[:len(expectedField.RawData)-len(expectedInferences.RawDataStripped)]
+			actualPaddedBytes := actualField.RawData[:len(actualField.RawData)-len(actualInferences.RawDataStripped)]
+			detail = fmt.Sprintf(`expected padded bytes "%x"; got "%x"`, expectedPaddedBytes, actualPaddedBytes)
+		}
+		divergences = append(
+			divergences,
+			Divergence{
+				Section: SectionData,
+				Index:   i,
+				Path:    pathOf(i),
+				Detail:  detail,
+			},
+		)
+		break
+	}
+
+	return divergences
+}
+
+// firstDifferentField compares the exported fields of two structs of the same type, skipping the inferences, and
+// returns the JSON name and the values of the first field that differs.
+func firstDifferentField(expected any, actual any) (string, any, any, bool) {
+	expectedValue := reflect.ValueOf(expected)
+	actualValue := reflect.ValueOf(actual)
+	structType := expectedValue.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		structField := structType.Field(i)
+		if !structField.IsExported() || structField.Name == "Inferences" {
+			continue
+		}
+		expectedFieldValue := expectedValue.Field(i).Interface()
+		actualFieldValue := actualValue.Field(i).Interface()
+		if !reflect.DeepEqual(expectedFieldValue, actualFieldValue) {
+			name := strings.Split(structField.Tag.Get("json"), ",")[0]
+			return name, expectedFieldValue, actualFieldValue, true
+		}
+	}
+	return "", nil, nil, false
+}
+
+func (r Divergence) String() string {
+	location := string(r.Section)
+	if r.Index >= 0 {
+		location += fmt.Sprintf(" #%d", r.Index)
+	}
+	if len(r.Path) > 0 {
+		location += " (" + strings.Join(r.Path, ".") + ")"
+	}
+	return location + ": " + r.Detail
+}
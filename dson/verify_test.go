@@ -0,0 +1,87 @@
+package dson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func readStruct(t *testing.T, path string) *dstruct.Struct {
+	fileBytes, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+	return file
+}
+
+func TestVerify(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.roster.json")
+	require.NoError(t, err)
+	file := readStruct(t, "../sample_dson/persist.roster.json")
+
+	divergences, err := Verify(fileBytes, nil)
+	require.NoError(t, err)
+	require.Equal(t, []Section{SectionMeta2Block, SectionData}, []Section{divergences[0].Section, divergences[1].Section})
+	require.Equal(
+		t,
+		"data #10 (base_root.heroes.56.hero_file_data.raw_data.base_root.actor.current_hp): "+
+			`expected padded bytes "745f68"; got "000000"`,
+		divergences[1].String(),
+	)
+
+	divergences, err = Verify(fileBytes, dstruct.Annotate(*file))
+	require.NoError(t, err)
+	require.Empty(t, divergences)
+}
+
+func TestVerify_TrailingBytes(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.estate.json")
+	require.NoError(t, err)
+	file := readStruct(t, "../sample_dson/persist.estate.json")
+	annotations := dstruct.Annotate(*file)
+
+	// the decoded structs are the same, since the decoder does not read past the data block
+	fileBytes = append(fileBytes, 0, 0, 0, 0)
+	divergences, err := Verify(fileBytes, annotations)
+	require.NoError(t, err)
+	require.Len(t, divergences, 1)
+	require.Equal(t, SectionBytes, divergences[0].Section)
+	require.Equal(t, len(fileBytes)-4, divergences[0].Index)
+	require.Equal(t, fmt.Sprintf(`expected "%d" bytes; got "%d"`, len(fileBytes), len(fileBytes)-4), divergences[0].Detail)
+}
+
+func TestVerify_CorruptedLength(t *testing.T) {
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.estate.json")
+	require.NoError(t, err)
+	file := readStruct(t, "../sample_dson/persist.estate.json")
+	annotations := dstruct.Annotate(*file)
+
+	// header_length comes right after the magic number and the revision; the decoder does not need it, so the file
+	// can still be decoded
+	headerLengthOffset := 8
+	corruptedBytes := append([]byte{}, fileBytes...)
+	binary.LittleEndian.PutUint32(corruptedBytes[headerLengthOffset:], uint32(file.Header.HeaderLength+1))
+	divergences, err := Verify(corruptedBytes, annotations)
+	require.NoError(t, err)
+	require.NotEmpty(t, divergences)
+	require.Equal(t, SectionHeader, divergences[0].Section)
+	require.Contains(t, divergences[0].Detail, "header_length")
+}
+
+func TestVerify_DuplicatedField(t *testing.T) {
+	file := readStruct(t, "../sample_dson/persist.progression.json")
+
+	fileBytes, err := ioutil.ReadFile("../sample_dson/persist.progression.json")
+	require.NoError(t, err)
+	divergences, err := Verify(fileBytes, dstruct.Annotate(*file))
+	require.NoError(t, err)
+	require.Empty(t, divergences)
+
+	duplicatedFields := Query(*file, "base_root.real_achievements.slay_a_squiffy_with_jester__duplicated_dont_touch_2")
+	require.Len(t, duplicatedFields, 1)
+	require.Equal(t, "slay_a_squiffy_with_jester", duplicatedFields[0].Field.Name)
+}
@@ -0,0 +1,120 @@
+package dson
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// MarshalYAML works like `json.Marshal`, but writes block-style YAML. The value is marshalled to JSON first, so the
+// keys of ordered maps keep their order, and the values look the same as within the JSON files.
+func MarshalYAML(value any) ([]byte, error) {
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		err := errors.Wrap(err, "dson.MarshalYAML error marshalling JSON")
+		return nil, err
+	}
+	// JSON is valid YAML, and a YAML node keeps the order of the keys
+	node := yaml.Node{}
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		err := errors.Wrap(err, "dson.MarshalYAML error unmarshalling JSON")
+		return nil, err
+	}
+	resetYAMLStyle(&node)
+
+	buffer := bytes.Buffer{}
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		err := errors.Wrap(err, "dson.MarshalYAML error encoding")
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		err := errors.Wrap(err, "dson.MarshalYAML error encoding")
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// resetYAMLStyle drops the JSON-like style (flow mappings, and double-quoted strings) of the nodes that were
+// unmarshalled from JSON. Sequences of scalars (vectors) are still kept within one line, since they are short.
+func resetYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+	if node.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, child := range node.Content {
+		if child.Kind != yaml.ScalarNode {
+			return
+		}
+	}
+	node.Style = yaml.FlowStyle
+}
+
+// UnmarshalYAML reads a YAML document into an ordered map. The values have the same types as what `json.Unmarshal`
+// gives (numbers are float64, arrays are []any, and objects are ordered maps), so the data types of the fields are
+// implied the same way as with JSON files.
+func UnmarshalYAML(bs []byte) (*orderedmap.OrderedMap, error) {
+	node := yaml.Node{}
+	if err := yaml.Unmarshal(bs, &node); err != nil {
+		err := errors.Wrap(err, "dson.UnmarshalYAML error")
+		return nil, err
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("dson.UnmarshalYAML error: the document is not a mapping")
+	}
+	value, err := fromYAMLNode(node.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	lhm := value.(orderedmap.OrderedMap)
+	return &lhm, nil
+}
+
+func fromYAMLNode(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		lhm := orderedmap.New()
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := fromYAMLNode(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			lhm.Set(node.Content[i].Value, value)
+		}
+		return *lhm, nil
+	case yaml.SequenceNode:
+		values := make([]any, 0, len(node.Content))
+		for _, child := range node.Content {
+			value, err := fromYAMLNode(child)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	case yaml.AliasNode:
+		return fromYAMLNode(node.Alias)
+	}
+
+	var value any
+	if err := node.Decode(&value); err != nil {
+		err := errors.Wrapf(err, "dson.UnmarshalYAML error decoding value at line %d", node.Line)
+		return nil, err
+	}
+	switch value.(type) {
+	case int:
+		return float64(value.(int)), nil
+	case int64:
+		return float64(value.(int64)), nil
+	case uint64:
+		return float64(value.(uint64)), nil
+	}
+	return value, nil
+}
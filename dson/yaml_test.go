@@ -0,0 +1,75 @@
+package dson
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/stretchr/testify/require"
+)
+
+func TestYAMLRoundTrip(t *testing.T) {
+	filePaths, err := filepath.Glob("../sample_dson/*.json")
+	require.NoError(t, err)
+	for _, filePath := range filePaths {
+		fileBytes, err := ioutil.ReadFile(filePath)
+		require.NoError(t, err)
+
+		// converting through YAML should give the same result as converting through JSON
+		jsonBytes, err := DecodeDSON(fileBytes, false)
+		require.NoError(t, err, filePath)
+		expectedBytes, err := EncodeJSON(jsonBytes)
+		require.NoError(t, err, filePath)
+
+		yamlBuffer := bytes.Buffer{}
+		decoder := NewDecoder(bytes.NewReader(fileBytes))
+		decoder.SetFormat(FormatYAML)
+		annotationsBuffer := bytes.Buffer{}
+		decoder.SetAnnotationsWriter(&annotationsBuffer)
+		require.NoError(t, decoder.DecodeJSON(&yamlBuffer), filePath)
+
+		dsonBuffer := bytes.Buffer{}
+		encoder := NewEncoder(&dsonBuffer)
+		encoder.SetFormat(FormatYAML)
+		require.NoError(t, encoder.EncodeJSON(bytes.NewReader(yamlBuffer.Bytes())), filePath)
+		require.Equal(t, expectedBytes, dsonBuffer.Bytes(), filePath)
+
+		// and the annotations work the same way
+		annotations, err := ReadAnnotations(&annotationsBuffer)
+		require.NoError(t, err, filePath)
+		dsonBuffer.Reset()
+		encoder.SetAnnotations(annotations)
+		require.NoError(t, encoder.EncodeJSON(bytes.NewReader(yamlBuffer.Bytes())), filePath)
+		require.Equal(t, fileBytes, dsonBuffer.Bytes(), filePath)
+	}
+}
+
+func TestMarshalYAML(t *testing.T) {
+	lhm, err := UnmarshalYAML([]byte(`{"b": {"c": [1, "###d"], "a": true}, "a": "true", "e": 1.5}`))
+	require.NoError(t, err)
+
+	yamlBytes, err := MarshalYAML(lhm)
+	require.NoError(t, err)
+	expected := "" +
+		"b:\n" +
+		"  c: [1, '###d']\n" +
+		"  a: true\n" +
+		"a: \"true\"\n" +
+		"e: 1.5\n"
+	require.Equal(t, expected, string(yamlBytes))
+
+	lhm, err = UnmarshalYAML(yamlBytes)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a", "e"}, lhm.Keys())
+	value, _ := lhm.Get("b")
+	b := value.(orderedmap.OrderedMap)
+	c, _ := b.Get("c")
+	require.Equal(t, []any{float64(1), "###d"}, c)
+	a, _ := lhm.Get("a")
+	require.Equal(t, "true", a)
+
+	_, err = UnmarshalYAML([]byte("- 1\n- 2\n"))
+	require.Error(t, err)
+}
@@ -117,6 +117,10 @@ func matchValue(pattern interface{}, value interface{}) ([]MatchItem, bool) {
 		return nil, true
 	}
 
+	if _, ok := pattern.(oneOfContainer); ok {
+		return nil, oneOfContainerPatternMatch(pattern, value)
+	}
+
 	valueKind := reflect.TypeOf(value).Kind()
 	valueIsSimpleType := containsKind(simpleTypes, valueKind)
 
@@ -0,0 +1,72 @@
+// Package profile stores the code to work with a whole save folder (a "profile" in Darkest Dungeon's terms),
+// which consists of DSON files that must stay consistent with each other.
+package profile
+
+import (
+	"fmt"
+
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	Profile struct {
+		Path string
+		// Files are sorted by their names
+		Files []File
+	}
+	File struct {
+		Name   string
+		Struct dstruct.Struct
+		// originalBytes are used to find out if the file was changed
+		originalBytes []byte
+	}
+
+	ErrFileNotFound struct {
+		Caller string
+		Path   string
+		Name   string
+	}
+)
+
+const (
+	FileNameNoveltyTracker = "novelty_tracker.json"
+	FileNameCampaignLog    = "persist.campaign_log.json"
+	FileNameCampaignMash   = "persist.campaign_mash.json"
+	FileNameCurioTracker   = "persist.curio_tracker.json"
+	FileNameEstate         = "persist.estate.json"
+	FileNameGame           = "persist.game.json"
+	FileNameGameKnowledge  = "persist.game_knowledge.json"
+	FileNameJournal        = "persist.journal.json"
+	FileNameNarration      = "persist.narration.json"
+	FileNameProgression    = "persist.progression.json"
+	FileNameQuest          = "persist.quest.json"
+	FileNameRoster         = "persist.roster.json"
+	FileNameTown           = "persist.town.json"
+	FileNameTownEvent      = "persist.town_event.json"
+	FileNameTutorial       = "persist.tutorial.json"
+	FileNameUpgrades       = "persist.upgrades.json"
+)
+
+// FileNames are the files that are usually found in a profile folder.
+var FileNames = []string{
+	FileNameNoveltyTracker,
+	FileNameCampaignLog,
+	FileNameCampaignMash,
+	FileNameCurioTracker,
+	FileNameEstate,
+	FileNameGame,
+	FileNameGameKnowledge,
+	FileNameJournal,
+	FileNameNarration,
+	FileNameProgression,
+	FileNameQuest,
+	FileNameRoster,
+	FileNameTown,
+	FileNameTownEvent,
+	FileNameTutorial,
+	FileNameUpgrades,
+}
+
+func (r ErrFileNotFound) Error() string {
+	return fmt.Sprintf(`%s: file "%s" not found in profile "%s"`, r.Caller, r.Name, r.Path)
+}
@@ -0,0 +1,158 @@
+package profile
+
+import (
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+type (
+	// Summary describes a profile folder that was discovered, with what the game shows on the profile selection
+	// screen.
+	Summary struct {
+		Path       string
+		EstateName string
+		GameMode   string
+		// DateTime is when the profile was last saved, like "2021-11-28 16:06:00".
+		DateTime string
+	}
+)
+
+// SteamAppID is the ID of Darkest Dungeon on Steam, which is the name of its folder within Steam's `userdata`.
+const SteamAppID = "262060"
+
+// maxDiscoveryDepth limits how deep the roots are scanned, which is enough for
+// `userdata/<user ID>/262060/remote/profile_0`.
+const maxDiscoveryDepth = 5
+
+// IsDSONFile tells whether the file at path starts with the DSON magic number.
+func IsDSONFile(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+	magicNumber := make([]byte, 4)
+	if _, err := file.Read(magicNumber); err != nil {
+		return false
+	}
+	return dson.IsDSONFile(magicNumber)
+}
+
+// IsProfileFolder tells whether the folder is a profile, which has `persist.game.json` as a DSON file.
+func IsProfileFolder(path string) bool {
+	return IsDSONFile(filepath.Join(path, FileNameGame))
+}
+
+// DefaultRoots returns the folders where the profiles usually are: Steam's `userdata` folders, and the documents
+// folder that is used by the GOG version on Windows. Some of them might not exist.
+func DefaultRoots() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return []string{}
+	}
+	switch runtime.GOOS {
+	case "windows":
+		return []string{
+			filepath.Join(os.Getenv("ProgramFiles(x86)"), "Steam", "userdata"),
+			filepath.Join(home, "Documents", "Darkest"),
+		}
+	case "darwin":
+		return []string{
+			filepath.Join(home, "Library", "Application Support", "Steam", "userdata"),
+		}
+	}
+	return []string{
+		filepath.Join(home, ".steam", "steam", "userdata"),
+		filepath.Join(home, ".local", "share", "Steam", "userdata"),
+		filepath.Join(home, ".var", "app", "com.valvesoftware.Steam", ".local", "share", "Steam", "userdata"),
+	}
+}
+
+// Discover scans the roots (and their sub-folders) for profile folders. Roots that do not exist are skipped, and so
+// are hidden folders, like the backups, and the sub-folders that cannot be read.
+func Discover(roots []string) ([]Summary, error) {
+	summaries := make([]Summary, 0)
+	for _, root := range roots {
+		if _, err := os.Stat(root); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		rootSummaries, err := discoverWithin(os.DirFS(root), root)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, rootSummaries...)
+	}
+	return summaries, nil
+}
+
+// discoverWithin scans fsys, which is the file system of the folder at root. Only an error reading root itself is
+// returned, since roots like Steam's `userdata` also hold the folders of other games, which might not be readable.
+func discoverWithin(fsys fs.FS, root string) ([]Summary, error) {
+	summaries := make([]Summary, 0)
+	err := fs.WalkDir(
+		fsys,
+		".",
+		func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				if path == "." {
+					return err
+				}
+				return filepath.SkipDir
+			}
+			if !entry.IsDir() {
+				return nil
+			}
+			if path != "." && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			folderPath := filepath.Join(root, filepath.FromSlash(path))
+			if IsProfileFolder(folderPath) {
+				summaries = append(summaries, Summarize(folderPath))
+				return filepath.SkipDir
+			}
+			if path != "." && len(strings.Split(path, "/")) >= maxDiscoveryDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		err := errors.Wrapf(err, `profile.Discover error scanning "%s"`, root)
+		return nil, err
+	}
+	return summaries, nil
+}
+
+// Summarize reads the estate name, the game mode, and the last saved time of the profile at path. They are left
+// empty if `persist.game.json` cannot be decoded.
+func Summarize(path string) Summary {
+	summary := Summary{Path: path}
+	fileBytes, err := ioutil.ReadFile(filepath.Join(path, FileNameGame))
+	if err != nil {
+		return summary
+	}
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	if err != nil {
+		return summary
+	}
+	readString := func(path string) string {
+		for _, flatField := range dson.Query(*file, path) {
+			if value, ok := flatField.Field.Inferences.Data.(string); ok {
+				return value
+			}
+		}
+		return ""
+	}
+	summary.EstateName = readString("base_root.estatename")
+	summary.GameMode = readString("base_root.game_mode")
+	summary.DateTime = readString("base_root.date_time")
+	return summary
+}
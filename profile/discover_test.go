@@ -0,0 +1,72 @@
+package profile
+
+import (
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unreadableFS fails to open the folders with the names, like folders without the read permission.
+type unreadableFS struct {
+	fs.FS
+	names []string
+}
+
+func (r unreadableFS) Open(name string) (fs.File, error) {
+	for _, unreadableName := range r.names {
+		if name == unreadableName {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrPermission}
+		}
+	}
+	return r.FS.Open(name)
+}
+
+func createSteamProfile(t *testing.T, root string, userID string) string {
+	profilePath := filepath.Join(root, userID, SteamAppID, "remote", "profile_0")
+	require.NoError(t, os.MkdirAll(profilePath, 0755))
+	fileBytes, err := ioutil.ReadFile(filepath.Join("../sample_dson", FileNameGame))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(profilePath, FileNameGame), fileBytes, 0644))
+	return profilePath
+}
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	profilePath := createSteamProfile(t, root, "123")
+
+	// converted to JSON, which is not a profile
+	convertedPath := filepath.Join(root, "123", SteamAppID, "remote", "profile_1")
+	require.NoError(t, os.MkdirAll(convertedPath, 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(convertedPath, FileNameGame), []byte("{}"), 0644))
+
+	assert.True(t, IsProfileFolder(profilePath))
+	assert.False(t, IsProfileFolder(convertedPath))
+	assert.False(t, IsProfileFolder(root))
+
+	summaries, err := Discover([]string{filepath.Join(root, "missing"), root})
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, profilePath, summaries[0].Path)
+	assert.Equal(t, "Second", summaries[0].EstateName)
+	assert.NotEmpty(t, summaries[0].DateTime)
+}
+
+func TestDiscover_UnreadableFolder(t *testing.T) {
+	root := t.TempDir()
+	// the unreadable folder comes before the profile, so the scan has to go on after it
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "123", "241100"), 0755))
+	profilePath := createSteamProfile(t, root, "123")
+
+	summaries, err := discoverWithin(unreadableFS{os.DirFS(root), []string{"123/241100"}}, root)
+	require.NoError(t, err)
+	require.Len(t, summaries, 1)
+	assert.Equal(t, profilePath, summaries[0].Path)
+
+	_, err = discoverWithin(unreadableFS{os.DirFS(root), []string{"."}}, root)
+	assert.ErrorIs(t, err, fs.ErrPermission)
+}
@@ -0,0 +1,91 @@
+package profile
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// Load decodes every DSON file within the folder. Other files (JSON files that were converted by the user, backups,
+// etc.) are skipped. The folder must have at least `persist.game.json`.
+func Load(path string) (*Profile, error) {
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		err := errors.Wrap(err, "profile.Load error reading folder")
+		return nil, err
+	}
+	profile := Profile{
+		Path:  path,
+		Files: make([]File, 0, len(FileNames)),
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		fileBytes, err := ioutil.ReadFile(filepath.Join(path, entry.Name()))
+		if err != nil {
+			err := errors.Wrapf(err, `profile.Load error reading file "%s"`, entry.Name())
+			return nil, err
+		}
+		if len(fileBytes) < 4 || !dson.IsDSONFile(fileBytes) {
+			continue
+		}
+		file, err := dstruct.ToStructuredFile(fileBytes)
+		if err != nil {
+			err := errors.Wrapf(err, `profile.Load error decoding file "%s"`, entry.Name())
+			return nil, err
+		}
+		profile.Files = append(
+			profile.Files,
+			File{
+				Name:          entry.Name(),
+				Struct:        *file,
+				originalBytes: fileBytes,
+			},
+		)
+	}
+	sort.Slice(
+		profile.Files,
+		func(i, j int) bool { return profile.Files[i].Name < profile.Files[j].Name },
+	)
+
+	if profile.File(FileNameGame) == nil {
+		return nil, ErrFileNotFound{
+			Caller: "profile.Load",
+			Path:   path,
+			Name:   FileNameGame,
+		}
+	}
+	return &profile, nil
+}
+
+// File returns the file with the name, or nil if the profile does not have it.
+func (r *Profile) File(name string) *File {
+	for i := range r.Files {
+		if r.Files[i].Name == name {
+			return &r.Files[i]
+		}
+	}
+	return nil
+}
+
+func (r *Profile) mustFile(caller string, name string) (*File, error) {
+	file := r.File(name)
+	if file == nil {
+		return nil, ErrFileNotFound{
+			Caller: caller,
+			Path:   r.Path,
+			Name:   name,
+		}
+	}
+	return file, nil
+}
+
+// Changed reports whether the file's struct is different from what was read.
+func (r *File) Changed() bool {
+	return string(dstruct.EncodeStruct(r.Struct)) != string(r.originalBytes)
+}
@@ -0,0 +1,48 @@
+package profile
+
+import (
+	"github.com/thanhnguyen2187/darkest-savior/saves/estate"
+	"github.com/thanhnguyen2187/darkest-savior/saves/roster"
+)
+
+func (r *Profile) Roster() (*roster.Roster, error) {
+	file, err := r.mustFile("Profile.Roster", FileNameRoster)
+	if err != nil {
+		return nil, err
+	}
+	return roster.FromStruct(file.Struct)
+}
+
+func (r *Profile) SetRoster(value roster.Roster) error {
+	file, err := r.mustFile("Profile.SetRoster", FileNameRoster)
+	if err != nil {
+		return err
+	}
+	fileStruct, err := roster.ToStruct(value)
+	if err != nil {
+		return err
+	}
+	file.Struct = *fileStruct
+	return nil
+}
+
+func (r *Profile) Estate() (*estate.Estate, error) {
+	file, err := r.mustFile("Profile.Estate", FileNameEstate)
+	if err != nil {
+		return nil, err
+	}
+	return estate.FromStruct(file.Struct)
+}
+
+func (r *Profile) SetEstate(value estate.Estate) error {
+	file, err := r.mustFile("Profile.SetEstate", FileNameEstate)
+	if err != nil {
+		return err
+	}
+	fileStruct, err := estate.ToStruct(value)
+	if err != nil {
+		return err
+	}
+	file.Struct = *fileStruct
+	return nil
+}
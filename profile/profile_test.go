@@ -0,0 +1,101 @@
+package profile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/saves/estate"
+)
+
+func copySampleProfile(t *testing.T) string {
+	path := t.TempDir()
+	entries, err := ioutil.ReadDir("../sample_dson")
+	require.NoError(t, err)
+	for _, entry := range entries {
+		bs, err := ioutil.ReadFile(filepath.Join("../sample_dson", entry.Name()))
+		require.NoError(t, err)
+		err = ioutil.WriteFile(filepath.Join(path, entry.Name()), bs, 0644)
+		require.NoError(t, err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := copySampleProfile(t)
+	// files that are not DSON are skipped
+	err := ioutil.WriteFile(filepath.Join(path, "converted.json"), []byte("{}"), 0644)
+	require.NoError(t, err)
+
+	profile, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, profile.Files, len(FileNames))
+	for _, name := range FileNames {
+		require.NotNil(t, profile.File(name), name)
+	}
+	require.Empty(t, profile.ChangedFiles())
+}
+
+func TestLoad_NotAProfile(t *testing.T) {
+	_, err := Load(t.TempDir())
+	require.ErrorAs(t, err, &ErrFileNotFound{})
+}
+
+func TestProfile_Save(t *testing.T) {
+	path := copySampleProfile(t)
+	profile, err := Load(path)
+	require.NoError(t, err)
+
+	estateModel, err := profile.Estate()
+	require.NoError(t, err)
+	estateModel.SetAmount(estate.CurrencyGold, 12345)
+	err = profile.SetEstate(*estateModel)
+	require.NoError(t, err)
+	require.Equal(t, []string{FileNameEstate}, profile.ChangedFiles())
+
+	err = profile.Save()
+	require.NoError(t, err)
+	require.Empty(t, profile.ChangedFiles())
+
+	entries, err := ioutil.ReadDir(path)
+	require.NoError(t, err)
+	require.Len(t, entries, len(FileNames)+1, "temporary files should be cleaned up")
+	snapshots, err := backup.List(path)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+
+	for _, name := range FileNames {
+		if name == FileNameEstate {
+			continue
+		}
+		expected, err := ioutil.ReadFile(filepath.Join("../sample_dson", name))
+		require.NoError(t, err)
+		actual, err := ioutil.ReadFile(filepath.Join(path, name))
+		require.NoError(t, err)
+		require.Equal(t, expected, actual, name)
+	}
+
+	profile, err = Load(path)
+	require.NoError(t, err)
+	estateModel, err = profile.Estate()
+	require.NoError(t, err)
+	require.Equal(t, 12345, estateModel.Amount(estate.CurrencyGold))
+}
+
+func TestProfile_Save_Failed(t *testing.T) {
+	path := copySampleProfile(t)
+	profile, err := Load(path)
+	require.NoError(t, err)
+
+	profile.File(FileNameEstate).Struct.Header.Revision += 1
+	profile.Path = filepath.Join(path, "missing")
+	err = profile.Save()
+	require.Error(t, err)
+	require.Equal(t, []string{FileNameEstate}, profile.ChangedFiles())
+
+	_, err = os.Stat(profile.Path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -0,0 +1,111 @@
+package profile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// ChangedFiles returns the names of the files that were changed since the profile was loaded or saved.
+func (r *Profile) ChangedFiles() []string {
+	names := make([]string, 0)
+	for _, file := range r.Files {
+		if file.Changed() {
+			names = append(names, file.Name)
+		}
+	}
+	return names
+}
+
+// Save writes the changed files back into the profile folder. The folder is backed up first.
+//
+// Every changed file is written into a temporary file next to it first. Only when all of them were written
+// successfully, they are renamed to replace the actual files, so a failure midway does not leave a profile where
+// some files were updated while the others were not.
+func (r *Profile) Save() error {
+	type pendingFile struct {
+		index    int
+		bytes    []byte
+		tempPath string
+	}
+	pendingFiles := make([]pendingFile, 0)
+	removeTempFiles := func() {
+		for _, pending := range pendingFiles {
+			_ = os.Remove(pending.tempPath)
+		}
+	}
+
+	if len(r.ChangedFiles()) == 0 {
+		return nil
+	}
+	if _, err := backup.CreateForFolder(r.Path); err != nil {
+		err := errors.Wrap(err, "Profile.Save error backing up")
+		return err
+	}
+
+	for i, file := range r.Files {
+		if !file.Changed() {
+			continue
+		}
+		fileBytes := dstruct.EncodeStruct(file.Struct)
+		tempPath, err := writeTempFile(r.Path, file.Name, fileBytes)
+		if err != nil {
+			removeTempFiles()
+			err := errors.Wrapf(err, `Profile.Save error writing "%s"`, file.Name)
+			return err
+		}
+		pendingFiles = append(
+			pendingFiles,
+			pendingFile{
+				index:    i,
+				bytes:    fileBytes,
+				tempPath: tempPath,
+			},
+		)
+	}
+
+	var result error
+	for _, pending := range pendingFiles {
+		file := &r.Files[pending.index]
+		if err := os.Rename(pending.tempPath, filepath.Join(r.Path, file.Name)); err != nil {
+			_ = os.Remove(pending.tempPath)
+			err := errors.Wrapf(err, `Profile.Save error replacing "%s"`, file.Name)
+			result = multierror.Append(result, err)
+			continue
+		}
+		file.originalBytes = pending.bytes
+	}
+	return result
+}
+
+func writeTempFile(folderPath string, name string, bs []byte) (string, error) {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(filepath.Join(folderPath, name)); err == nil {
+		mode = info.Mode()
+	}
+	tempFile, err := ioutil.TempFile(folderPath, "."+name+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	tempPath := tempFile.Name()
+	_, err = tempFile.Write(bs)
+	if err == nil {
+		err = tempFile.Sync()
+	}
+	if errClose := tempFile.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Chmod(tempPath, mode)
+	}
+	if err != nil {
+		_ = os.Remove(tempPath)
+		return "", err
+	}
+	return tempPath, nil
+}
@@ -0,0 +1,48 @@
+package saves
+
+import (
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+)
+
+// UnmarshalCollection reads an object whose keys are data (hero IDs, quirk names, running indexes, etc.)
+// into a slice, which keeps the keys' order.
+func UnmarshalCollection[T any](
+	node orderedmap.OrderedMap,
+	unmarshal func(key string, value any) (T, error),
+) ([]T, error) {
+	ts := make([]T, 0, len(node.Keys()))
+	for _, key := range node.Keys() {
+		value, _ := node.Get(key)
+		t, err := unmarshal(key, value)
+		if err != nil {
+			err := errors.Wrapf(err, `saves.UnmarshalCollection error at key "%s"`, key)
+			return nil, err
+		}
+		ts = append(ts, t)
+	}
+	return ts, nil
+}
+
+// MarshalCollection is the reversed process of UnmarshalCollection.
+func MarshalCollection[T any](
+	ts []T,
+	marshal func(t T, index int) (key string, value any, err error),
+) (orderedmap.OrderedMap, error) {
+	node := orderedmap.New()
+	for i, t := range ts {
+		key, value, err := marshal(t, i)
+		if err != nil {
+			err := errors.Wrapf(err, `saves.MarshalCollection error at index %d`, i)
+			return orderedmap.OrderedMap{}, err
+		}
+		if _, ok := node.Get(key); ok {
+			return orderedmap.OrderedMap{}, ErrDuplicatedKey{
+				Caller: "saves.MarshalCollection",
+				Key:    key,
+			}
+		}
+		node.Set(key, value)
+	}
+	return *node, nil
+}
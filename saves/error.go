@@ -0,0 +1,33 @@
+package saves
+
+import (
+	"fmt"
+	"strings"
+)
+
+type (
+	ErrKeyNotFound struct {
+		Caller string
+		Keys   []string
+	}
+	ErrNotAnObject struct {
+		Caller string
+		Keys   []string
+	}
+	ErrDuplicatedKey struct {
+		Caller string
+		Key    string
+	}
+)
+
+func (r ErrKeyNotFound) Error() string {
+	return fmt.Sprintf(`%s: key "%s" not found`, r.Caller, strings.Join(r.Keys, "."))
+}
+
+func (r ErrNotAnObject) Error() string {
+	return fmt.Sprintf(`%s: value at key "%s" is not an object`, r.Caller, strings.Join(r.Keys, "."))
+}
+
+func (r ErrDuplicatedKey) Error() string {
+	return fmt.Sprintf(`%s: duplicated key "%s"`, r.Caller, r.Key)
+}
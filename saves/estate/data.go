@@ -0,0 +1,95 @@
+// Package estate stores a typed model of `persist.estate.json`, which contains the resources and the trinkets.
+package estate
+
+import (
+	"github.com/iancoleman/orderedmap"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/saves"
+)
+
+type (
+	Estate struct {
+		Version  int          `json:"version"`
+		Wallet   []Currency   `json:"-"`
+		Trinkets []saves.Item `json:"-"`
+		// node is the whole decoded file, including the revision field
+		node orderedmap.OrderedMap
+		// original is the struct that the estate was read from, if there is one
+		original *dstruct.Struct
+	}
+	Currency struct {
+		Amount int    `json:"amount"`
+		Type   string `json:"type"`
+		node   orderedmap.OrderedMap
+	}
+)
+
+const (
+	CurrencyGold      = "gold"
+	CurrencyBust      = "bust"
+	CurrencyPortrait  = "portrait"
+	CurrencyDeed      = "deed"
+	CurrencyCrest     = "crest"
+	CurrencyBlueprint = "blueprint"
+	CurrencyShard     = "shard"
+	CurrencyMemory    = "memory"
+
+	KeyBaseRoot     = "base_root"
+	KeyWallet       = "wallet"
+	KeyTrinkets     = "trinkets"
+	KeyTrinketItems = "items"
+)
+
+// Amount returns the amount of a currency, or 0 if the wallet does not have it.
+func (r *Estate) Amount(currencyType string) int {
+	for _, currency := range r.Wallet {
+		if currency.Type == currencyType {
+			return currency.Amount
+		}
+	}
+	return 0
+}
+
+// SetAmount changes the amount of a currency, and adds the currency to the wallet if it is not there yet.
+func (r *Estate) SetAmount(currencyType string, amount int) {
+	for i := range r.Wallet {
+		if r.Wallet[i].Type == currencyType {
+			r.Wallet[i].Amount = amount
+			return
+		}
+	}
+	r.Wallet = append(
+		r.Wallet,
+		Currency{
+			Amount: amount,
+			Type:   currencyType,
+			node:   *orderedmap.New(),
+		},
+	)
+}
+
+// CountTrinkets returns the total amount of the trinkets with the ID.
+func (r *Estate) CountTrinkets(id string) int {
+	count := 0
+	for _, trinket := range r.Trinkets {
+		if trinket.ID == id {
+			count += trinket.Amount
+		}
+	}
+	return count
+}
+
+func (r *Estate) AddTrinket(id string) {
+	r.Trinkets = append(r.Trinkets, saves.NewItem(id, saves.ItemTypeTrinket, 1))
+}
+
+// RemoveTrinket removes the last trinket with the ID, and reports whether there was one.
+func (r *Estate) RemoveTrinket(id string) bool {
+	for i := len(r.Trinkets) - 1; i >= 0; i-- {
+		if r.Trinkets[i].ID == id {
+			r.Trinkets = append(r.Trinkets[:i], r.Trinkets[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package estate
+
+import (
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/saves"
+)
+
+func FromStruct(file dstruct.Struct) (*Estate, error) {
+	node, err := dstruct.ToJSONNode(file)
+	if err != nil {
+		err := errors.Wrap(err, "estate.FromStruct error")
+		return nil, err
+	}
+	estate, err := FromNode(*node)
+	if err != nil {
+		return nil, err
+	}
+	estate.original = &file
+	return estate, nil
+}
+
+func FromNode(node orderedmap.OrderedMap) (*Estate, error) {
+	baseRoot, err := saves.GetNode(node, KeyBaseRoot)
+	if err != nil {
+		return nil, err
+	}
+	estate := Estate{}
+	if err := saves.Unmarshal(baseRoot, &estate); err != nil {
+		return nil, err
+	}
+
+	walletNode, err := saves.GetNode(baseRoot, KeyWallet)
+	if err != nil {
+		return nil, err
+	}
+	estate.Wallet, err = saves.UnmarshalCollection(
+		walletNode,
+		func(_ string, value any) (Currency, error) {
+			currency := Currency{}
+			if err := saves.Unmarshal(value, &currency); err != nil {
+				return Currency{}, err
+			}
+			currency.node, _ = value.(orderedmap.OrderedMap)
+			return currency, nil
+		},
+	)
+	if err != nil {
+		err := errors.Wrap(err, "estate.FromNode error")
+		return nil, err
+	}
+
+	trinketItemsNode, err := saves.GetNode(baseRoot, KeyTrinkets, KeyTrinketItems)
+	if err != nil {
+		return nil, err
+	}
+	estate.Trinkets, err = saves.UnmarshalItems(trinketItemsNode)
+	if err != nil {
+		err := errors.Wrap(err, "estate.FromNode error")
+		return nil, err
+	}
+	estate.node = node
+
+	return &estate, nil
+}
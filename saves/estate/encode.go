@@ -0,0 +1,56 @@
+package estate
+
+import (
+	"strconv"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/saves"
+)
+
+func ToStruct(estate Estate) (*dstruct.Struct, error) {
+	node, err := ToNode(estate)
+	if err != nil {
+		err := errors.Wrap(err, "estate.ToStruct error")
+		return nil, err
+	}
+	return dstruct.FromJSONNode(node, estate.original)
+}
+
+func ToNode(estate Estate) (orderedmap.OrderedMap, error) {
+	baseRoot, err := saves.GetNode(estate.node, KeyBaseRoot)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	baseRoot, err = saves.Merge(baseRoot, estate)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+
+	walletNode, err := saves.MarshalCollection(
+		estate.Wallet,
+		func(currency Currency, index int) (string, any, error) {
+			currencyNode, err := saves.Merge(currency.node, currency)
+			return strconv.Itoa(index), currencyNode, err
+		},
+	)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	baseRoot, err = saves.SetNode(baseRoot, walletNode, KeyWallet)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+
+	trinketItemsNode, err := saves.MarshalItems(estate.Trinkets)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	baseRoot, err = saves.SetNode(baseRoot, trinketItemsNode, KeyTrinkets, KeyTrinketItems)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+
+	return saves.SetNode(estate.node, baseRoot, KeyBaseRoot)
+}
@@ -0,0 +1,65 @@
+package estate
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func readEstate(t *testing.T) ([]byte, *Estate) {
+	fileBytes, err := ioutil.ReadFile("../../sample_dson/persist.estate.json")
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+	estate, err := FromStruct(*file)
+	require.NoError(t, err)
+	return fileBytes, estate
+}
+
+func TestFromStruct(t *testing.T) {
+	_, estate := readEstate(t)
+
+	require.Equal(t, 18275, estate.Amount(CurrencyGold))
+	require.Equal(t, 15, estate.Amount(CurrencyBust))
+	require.Equal(t, 6, estate.Amount(CurrencyPortrait))
+	require.Equal(t, 11, estate.Amount(CurrencyDeed))
+	require.Equal(t, 32, estate.Amount(CurrencyCrest))
+	require.Equal(t, 0, estate.Amount(CurrencyShard))
+	require.Equal(t, "berserk_mask", estate.Trinkets[0].ID)
+	require.Equal(t, 1, estate.CountTrinkets("berserk_mask"))
+}
+
+func TestToStruct_Untouched(t *testing.T) {
+	fileBytes, estate := readEstate(t)
+
+	file, err := ToStruct(*estate)
+	require.NoError(t, err)
+	require.Equal(t, fileBytes, dstruct.EncodeStruct(*file))
+}
+
+func TestToStruct_Edited(t *testing.T) {
+	_, estate := readEstate(t)
+	trinketsCount := len(estate.Trinkets)
+
+	estate.SetAmount(CurrencyGold, 50000)
+	estate.SetAmount(CurrencyShard, 10)
+	estate.AddTrinket("ancestors_map")
+	require.True(t, estate.RemoveTrinket("berserk_mask"))
+	require.False(t, estate.RemoveTrinket("berserk_mask"))
+
+	file, err := ToStruct(*estate)
+	require.NoError(t, err)
+	file, err = dstruct.ToStructuredFile(dstruct.EncodeStruct(*file))
+	require.NoError(t, err)
+	estate, err = FromStruct(*file)
+	require.NoError(t, err)
+
+	require.Equal(t, 50000, estate.Amount(CurrencyGold))
+	require.Equal(t, 10, estate.Amount(CurrencyShard))
+	require.Equal(t, 15, estate.Amount(CurrencyBust))
+	require.Len(t, estate.Trinkets, trinketsCount)
+	require.Equal(t, 0, estate.CountTrinkets("berserk_mask"))
+	require.Equal(t, "ancestors_map", estate.Trinkets[trinketsCount-1].ID)
+}
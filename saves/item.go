@@ -0,0 +1,59 @@
+package saves
+
+import (
+	"strconv"
+
+	"github.com/iancoleman/orderedmap"
+)
+
+type (
+	// Item is an entry of an item list, like the trinkets of the estate or of a hero.
+	Item struct {
+		ID     string `json:"id"`
+		Type   string `json:"type"`
+		Amount int    `json:"amount"`
+		node   orderedmap.OrderedMap
+	}
+)
+
+const (
+	ItemTypeTrinket = "trinket"
+)
+
+func NewItem(id string, itemType string, amount int) Item {
+	return Item{
+		ID:     id,
+		Type:   itemType,
+		Amount: amount,
+		node:   *orderedmap.New(),
+	}
+}
+
+func UnmarshalItems(node orderedmap.OrderedMap) ([]Item, error) {
+	return UnmarshalCollection(
+		node,
+		func(_ string, value any) (Item, error) {
+			item := Item{}
+			if err := Unmarshal(value, &item); err != nil {
+				return Item{}, err
+			}
+			item.node, _ = value.(orderedmap.OrderedMap)
+			return item, nil
+		},
+	)
+}
+
+// MarshalItems writes the items back with their running indexes as keys,
+// which is how the game lays item lists out.
+func MarshalItems(items []Item) (orderedmap.OrderedMap, error) {
+	return MarshalCollection(
+		items,
+		func(item Item, index int) (string, any, error) {
+			itemNode, err := Merge(item.node, item)
+			if err != nil {
+				return "", nil, err
+			}
+			return strconv.Itoa(index), itemNode, nil
+		},
+	)
+}
@@ -0,0 +1,113 @@
+// Package saves stores the shared code of the typed models that are built on top of decoded DSON files.
+//
+// A model keeps the "node" (the ordered map) that it was read from, so the fields that are not modeled
+// are written back untouched.
+package saves
+
+import (
+	"encoding/json"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+)
+
+// Unmarshal fills t with the values of node by using JSON as the bridge,
+// in the same spirit as `lbytes.ExecuteInstructions`.
+func Unmarshal(node any, t any) error {
+	nodeBytes, err := json.Marshal(node)
+	if err != nil {
+		err := errors.Wrap(err, "saves.Unmarshal error marshalling node")
+		return err
+	}
+	if err := json.Unmarshal(nodeBytes, t); err != nil {
+		err := errors.Wrapf(err, `saves.Unmarshal error unmarshalling to type "%T"`, t)
+		return err
+	}
+	return nil
+}
+
+// Merge writes the exported fields of t back into node. Keys that t does not know about keep their values and
+// their position; keys that only t has are appended. The input node is not modified.
+func Merge(node orderedmap.OrderedMap, t any) (orderedmap.OrderedMap, error) {
+	tBytes, err := json.Marshal(t)
+	if err != nil {
+		err := errors.Wrapf(err, `saves.Merge error marshalling type "%T"`, t)
+		return orderedmap.OrderedMap{}, err
+	}
+	tNode := orderedmap.New()
+	if err := json.Unmarshal(tBytes, tNode); err != nil {
+		err := errors.Wrapf(err, `saves.Merge error unmarshalling type "%T"`, t)
+		return orderedmap.OrderedMap{}, err
+	}
+	return MergeNodes(node, *tNode), nil
+}
+
+func MergeNodes(dst orderedmap.OrderedMap, src orderedmap.OrderedMap) orderedmap.OrderedMap {
+	result := orderedmap.New()
+	for _, key := range dst.Keys() {
+		dstValue, _ := dst.Get(key)
+		srcValue, ok := src.Get(key)
+		if !ok {
+			result.Set(key, dstValue)
+			continue
+		}
+		dstValueNode, ok1 := dstValue.(orderedmap.OrderedMap)
+		srcValueNode, ok2 := srcValue.(orderedmap.OrderedMap)
+		if ok1 && ok2 {
+			result.Set(key, MergeNodes(dstValueNode, srcValueNode))
+		} else {
+			result.Set(key, srcValue)
+		}
+	}
+	for _, key := range src.Keys() {
+		if _, ok := dst.Get(key); ok {
+			continue
+		}
+		srcValue, _ := src.Get(key)
+		result.Set(key, srcValue)
+	}
+	return *result
+}
+
+func GetNode(node orderedmap.OrderedMap, keys ...string) (orderedmap.OrderedMap, error) {
+	for i, key := range keys {
+		value, ok := node.Get(key)
+		if !ok {
+			return orderedmap.OrderedMap{}, ErrKeyNotFound{
+				Caller: "saves.GetNode",
+				Keys:   keys[:i+1],
+			}
+		}
+		valueNode, ok := value.(orderedmap.OrderedMap)
+		if !ok {
+			return orderedmap.OrderedMap{}, ErrNotAnObject{
+				Caller: "saves.GetNode",
+				Keys:   keys[:i+1],
+			}
+		}
+		node = valueNode
+	}
+	return node, nil
+}
+
+// SetNode returns a copy of node where the value at the nested keys is replaced.
+// Every object in between must exist.
+func SetNode(node orderedmap.OrderedMap, value any, keys ...string) (orderedmap.OrderedMap, error) {
+	if len(keys) == 0 {
+		err := errors.New("saves.SetNode error: empty keys")
+		return orderedmap.OrderedMap{}, err
+	}
+	if len(keys) > 1 {
+		child, err := GetNode(node, keys[0])
+		if err != nil {
+			return orderedmap.OrderedMap{}, err
+		}
+		value, err = SetNode(child, value, keys[1:]...)
+		if err != nil {
+			return orderedmap.OrderedMap{}, err
+		}
+	}
+	result := MergeNodes(node, orderedmap.OrderedMap{})
+	result.Set(keys[0], value)
+	return result, nil
+}
@@ -0,0 +1,203 @@
+// Package roster stores a typed model of `persist.roster.json`, which contains the current heroes.
+package roster
+
+import (
+	"regexp"
+
+	"github.com/iancoleman/orderedmap"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/saves"
+)
+
+type (
+	Roster struct {
+		Version            int    `json:"version"`
+		NextGUID           int    `json:"nextGuid"`
+		DismissedHeroCount int    `json:"dismissed_hero_count"`
+		HighestResolveXP   int    `json:"highest_resolve_xp"`
+		Heroes             []Hero `json:"-"`
+		// node is the whole decoded file, including the revision field
+		node orderedmap.OrderedMap
+		// original is the struct that the roster was read from, if there is one
+		original *dstruct.Struct
+	}
+	// Hero is the data within the embedded file `hero_file_data.raw_data` of each entry in `heroes`.
+	Hero struct {
+		// ID is the key of the hero within `heroes`
+		ID               string  `json:"-"`
+		Status           int     `json:"roster.status"`
+		Actor            Actor   `json:"actor"`
+		Class            string  `json:"heroClass"`
+		ResolveXP        int     `json:"resolveXp"`
+		Stress           float64 `json:"m_Stress"`
+		AfflictionTypeID string  `json:"affliction_type_id"`
+		VirtueTypeID     string  `json:"virtue_type_id"`
+		StepsTaken       int     `json:"steps_taken"`
+		EnemiesKilled    int     `json:"enemies_killed"`
+		Equipment
+		Quirks   []Quirk      `json:"-"`
+		Skills   Skills       `json:"-"`
+		Trinkets []saves.Item `json:"-"`
+		// node is the entry within `heroes`, which wraps the embedded file
+		node orderedmap.OrderedMap
+	}
+	Actor struct {
+		Name      string  `json:"name"`
+		CurrentHP float64 `json:"current_hp"`
+		Stunned   int     `json:"stunned"`
+	}
+	Equipment struct {
+		WeaponRank int `json:"weapon_rank"`
+		ArmourRank int `json:"armour_rank"`
+	}
+	Quirk struct {
+		// ID is the key of the quirk within `quirks`
+		ID           string `json:"-"`
+		IsNew        bool   `json:"is_new"`
+		IsLocked     bool   `json:"is_locked"`
+		MissionCount int    `json:"mission_count"`
+		node         orderedmap.OrderedMap
+	}
+	Skills struct {
+		Combat  []Skill
+		Camping []Skill
+		node    orderedmap.OrderedMap
+	}
+	// Skill is a selected skill of a hero with its upgrade level.
+	Skill struct {
+		ID    string
+		Level int
+	}
+)
+
+const (
+	KeyBaseRoot      = "base_root"
+	KeyHeroes        = "heroes"
+	KeyHeroFileData  = "hero_file_data"
+	KeyRawData       = "raw_data"
+	KeyQuirks        = "quirks"
+	KeySkills        = "skills"
+	KeyCombatSkills  = "selected_combat_skills"
+	KeyCampingSkills = "selected_camping_skills"
+	KeyTrinkets      = "trinkets"
+	KeyTrinketItems  = "items"
+
+	MaxStress = 200
+	// MaxHP is only a sanity bound, since the maximum HP of a hero depends on the class and the armour rank, which
+	// are not within the save
+	MaxHP = 1000
+)
+
+// quirkIDRegexp matches the IDs of the game's quirks (and diseases), like "unquiet_mind" or "the_red_plague".
+var quirkIDRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// ResolveXPThresholds are the resolve XP that a hero needs to reach each level, from level 0 to level 6.
+var ResolveXPThresholds = []int{0, 2, 8, 20, 36, 56, 82}
+
+// FindHero returns the hero with the ID, or nil if there is none.
+func (r *Roster) FindHero(id string) *Hero {
+	for i := range r.Heroes {
+		if r.Heroes[i].ID == id {
+			return &r.Heroes[i]
+		}
+	}
+	return nil
+}
+
+// NewQuirk creates a quirk that is not new to the player, and is not locked.
+func NewQuirk(id string) Quirk {
+	quirk := Quirk{
+		ID:   id,
+		node: *orderedmap.New(),
+	}
+	// the fields that are not modeled are laid out in the same order as the game's
+	quirk.node.Set("is_new", false)
+	quirk.node.Set("is_locked", false)
+	quirk.node.Set("mission_count", float64(0))
+	quirk.node.Set("replaces_quirk", float64(0))
+	quirk.node.Set("replaces_quirk_viewed", false)
+	quirk.node.Set("evolution_duration_remaining", float64(0))
+	return quirk
+}
+
+// Level returns the resolve level of the hero, which is the highest level whose threshold is reached.
+func (h *Hero) Level() int {
+	level := 0
+	for i, threshold := range ResolveXPThresholds {
+		if h.ResolveXP >= threshold {
+			level = i
+		}
+	}
+	return level
+}
+
+func (h *Hero) SetStress(stress float64) error {
+	if stress < 0 || stress > MaxStress {
+		return ErrOutOfRange{
+			Caller: "roster.SetStress",
+			Key:    "m_Stress",
+			Value:  stress,
+			Min:    0,
+			Max:    MaxStress,
+		}
+	}
+	h.Stress = stress
+	return nil
+}
+
+// SetCurrentHP changes the current HP of the hero, which must be positive, since heroes with no HP are dead.
+func (h *Hero) SetCurrentHP(hp float64) error {
+	if hp <= 0 || hp > MaxHP {
+		return ErrOutOfRange{
+			Caller: "roster.SetCurrentHP",
+			Key:    "current_hp",
+			Value:  hp,
+			Min:    1,
+			Max:    MaxHP,
+		}
+	}
+	h.Actor.CurrentHP = hp
+	return nil
+}
+
+// FindQuirk returns the quirk with the ID, or nil if the hero does not have it.
+func (h *Hero) FindQuirk(id string) *Quirk {
+	for i := range h.Quirks {
+		if h.Quirks[i].ID == id {
+			return &h.Quirks[i]
+		}
+	}
+	return nil
+}
+
+// AddQuirk adds a new quirk to the hero. The ID must look like the game's IDs (lowercase letters, digits, and
+// underscores), and the hero must not have the quirk yet.
+func (h *Hero) AddQuirk(id string) error {
+	if !quirkIDRegexp.MatchString(id) {
+		return ErrInvalidQuirkID{
+			Caller:  "roster.AddQuirk",
+			QuirkID: id,
+		}
+	}
+	if h.FindQuirk(id) != nil {
+		return ErrQuirkExisted{
+			Caller:  "roster.AddQuirk",
+			QuirkID: id,
+		}
+	}
+	h.Quirks = append(h.Quirks, NewQuirk(id))
+	return nil
+}
+
+func (h *Hero) RemoveQuirk(id string) error {
+	for i := range h.Quirks {
+		if h.Quirks[i].ID == id {
+			h.Quirks = append(h.Quirks[:i], h.Quirks[i+1:]...)
+			return nil
+		}
+	}
+	return ErrQuirkNotFound{
+		Caller:  "roster.RemoveQuirk",
+		QuirkID: id,
+	}
+}
@@ -0,0 +1,137 @@
+package roster
+
+import (
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/saves"
+)
+
+func FromStruct(file dstruct.Struct) (*Roster, error) {
+	node, err := dstruct.ToJSONNode(file)
+	if err != nil {
+		err := errors.Wrap(err, "roster.FromStruct error")
+		return nil, err
+	}
+	roster, err := FromNode(*node)
+	if err != nil {
+		return nil, err
+	}
+	roster.original = &file
+	return roster, nil
+}
+
+func FromNode(node orderedmap.OrderedMap) (*Roster, error) {
+	baseRoot, err := saves.GetNode(node, KeyBaseRoot)
+	if err != nil {
+		return nil, err
+	}
+	roster := Roster{}
+	if err := saves.Unmarshal(baseRoot, &roster); err != nil {
+		return nil, err
+	}
+	heroesNode, err := saves.GetNode(baseRoot, KeyHeroes)
+	if err != nil {
+		return nil, err
+	}
+	roster.Heroes, err = saves.UnmarshalCollection(
+		heroesNode,
+		func(key string, value any) (Hero, error) {
+			valueNode, ok := value.(orderedmap.OrderedMap)
+			if !ok {
+				return Hero{}, saves.ErrNotAnObject{
+					Caller: "roster.FromNode",
+					Keys:   []string{KeyBaseRoot, KeyHeroes, key},
+				}
+			}
+			return UnmarshalHero(key, valueNode)
+		},
+	)
+	if err != nil {
+		err := errors.Wrap(err, "roster.FromNode error")
+		return nil, err
+	}
+	roster.node = node
+
+	return &roster, nil
+}
+
+func UnmarshalHero(id string, node orderedmap.OrderedMap) (Hero, error) {
+	heroBaseRoot, err := saves.GetNode(node, KeyHeroFileData, KeyRawData, KeyBaseRoot)
+	if err != nil {
+		return Hero{}, err
+	}
+	hero := Hero{}
+	if err := saves.Unmarshal(heroBaseRoot, &hero); err != nil {
+		return Hero{}, err
+	}
+	hero.ID = id
+	hero.node = node
+
+	quirksNode, err := saves.GetNode(heroBaseRoot, KeyQuirks)
+	if err != nil {
+		return Hero{}, err
+	}
+	hero.Quirks, err = saves.UnmarshalCollection(quirksNode, UnmarshalQuirk)
+	if err != nil {
+		return Hero{}, err
+	}
+
+	skillsNode, err := saves.GetNode(heroBaseRoot, KeySkills)
+	if err != nil {
+		return Hero{}, err
+	}
+	hero.Skills, err = UnmarshalSkills(skillsNode)
+	if err != nil {
+		return Hero{}, err
+	}
+
+	trinketItemsNode, err := saves.GetNode(heroBaseRoot, KeyTrinkets, KeyTrinketItems)
+	if err != nil {
+		return Hero{}, err
+	}
+	hero.Trinkets, err = saves.UnmarshalItems(trinketItemsNode)
+	if err != nil {
+		return Hero{}, err
+	}
+
+	return hero, nil
+}
+
+func UnmarshalQuirk(id string, value any) (Quirk, error) {
+	quirk := Quirk{}
+	if err := saves.Unmarshal(value, &quirk); err != nil {
+		return Quirk{}, err
+	}
+	quirk.ID = id
+	quirk.node, _ = value.(orderedmap.OrderedMap)
+	return quirk, nil
+}
+
+func UnmarshalSkills(node orderedmap.OrderedMap) (Skills, error) {
+	unmarshalSkill := func(id string, value any) (Skill, error) {
+		skill := Skill{ID: id}
+		if err := saves.Unmarshal(value, &skill.Level); err != nil {
+			return Skill{}, err
+		}
+		return skill, nil
+	}
+	skills := Skills{node: node}
+	combatSkillsNode, err := saves.GetNode(node, KeyCombatSkills)
+	if err != nil {
+		return Skills{}, err
+	}
+	skills.Combat, err = saves.UnmarshalCollection(combatSkillsNode, unmarshalSkill)
+	if err != nil {
+		return Skills{}, err
+	}
+	campingSkillsNode, err := saves.GetNode(node, KeyCampingSkills)
+	if err != nil {
+		return Skills{}, err
+	}
+	skills.Camping, err = saves.UnmarshalCollection(campingSkillsNode, unmarshalSkill)
+	if err != nil {
+		return Skills{}, err
+	}
+	return skills, nil
+}
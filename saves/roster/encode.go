@@ -0,0 +1,110 @@
+package roster
+
+import (
+	"github.com/iancoleman/orderedmap"
+	"github.com/pkg/errors"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+	"github.com/thanhnguyen2187/darkest-savior/saves"
+)
+
+func ToStruct(roster Roster) (*dstruct.Struct, error) {
+	node, err := ToNode(roster)
+	if err != nil {
+		err := errors.Wrap(err, "roster.ToStruct error")
+		return nil, err
+	}
+	return dstruct.FromJSONNode(node, roster.original)
+}
+
+func ToNode(roster Roster) (orderedmap.OrderedMap, error) {
+	baseRoot, err := saves.GetNode(roster.node, KeyBaseRoot)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	baseRoot, err = saves.Merge(baseRoot, roster)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	heroesNode, err := saves.MarshalCollection(
+		roster.Heroes,
+		func(hero Hero, _ int) (string, any, error) {
+			heroNode, err := MarshalHero(hero)
+			return hero.ID, heroNode, err
+		},
+	)
+	if err != nil {
+		err := errors.Wrap(err, "roster.ToNode error")
+		return orderedmap.OrderedMap{}, err
+	}
+	baseRoot, err = saves.SetNode(baseRoot, heroesNode, KeyHeroes)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	return saves.SetNode(roster.node, baseRoot, KeyBaseRoot)
+}
+
+func MarshalHero(hero Hero) (orderedmap.OrderedMap, error) {
+	heroBaseRootKeys := []string{KeyHeroFileData, KeyRawData, KeyBaseRoot}
+	heroBaseRoot, err := saves.GetNode(hero.node, heroBaseRootKeys...)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	heroBaseRoot, err = saves.Merge(heroBaseRoot, hero)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+
+	quirksNode, err := saves.MarshalCollection(
+		hero.Quirks,
+		func(quirk Quirk, _ int) (string, any, error) {
+			quirkNode, err := saves.Merge(quirk.node, quirk)
+			return quirk.ID, quirkNode, err
+		},
+	)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	heroBaseRoot, err = saves.SetNode(heroBaseRoot, quirksNode, KeyQuirks)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+
+	skillsNode, err := MarshalSkills(hero.Skills)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	heroBaseRoot, err = saves.SetNode(heroBaseRoot, skillsNode, KeySkills)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+
+	trinketItemsNode, err := saves.MarshalItems(hero.Trinkets)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	heroBaseRoot, err = saves.SetNode(heroBaseRoot, trinketItemsNode, KeyTrinkets, KeyTrinketItems)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+
+	return saves.SetNode(hero.node, heroBaseRoot, heroBaseRootKeys...)
+}
+
+func MarshalSkills(skills Skills) (orderedmap.OrderedMap, error) {
+	marshalSkill := func(skill Skill, _ int) (string, any, error) {
+		return skill.ID, float64(skill.Level), nil
+	}
+	combatSkillsNode, err := saves.MarshalCollection(skills.Combat, marshalSkill)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	campingSkillsNode, err := saves.MarshalCollection(skills.Camping, marshalSkill)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	node, err := saves.SetNode(skills.node, combatSkillsNode, KeyCombatSkills)
+	if err != nil {
+		return orderedmap.OrderedMap{}, err
+	}
+	return saves.SetNode(node, campingSkillsNode, KeyCampingSkills)
+}
@@ -0,0 +1,43 @@
+package roster
+
+import (
+	"fmt"
+)
+
+type (
+	ErrOutOfRange struct {
+		Caller string
+		Key    string
+		Value  float64
+		Min    float64
+		Max    float64
+	}
+	ErrInvalidQuirkID struct {
+		Caller  string
+		QuirkID string
+	}
+	ErrQuirkExisted struct {
+		Caller  string
+		QuirkID string
+	}
+	ErrQuirkNotFound struct {
+		Caller  string
+		QuirkID string
+	}
+)
+
+func (r ErrOutOfRange) Error() string {
+	return fmt.Sprintf(`%s: value %v of "%s" is not within [%v, %v]`, r.Caller, r.Value, r.Key, r.Min, r.Max)
+}
+
+func (r ErrInvalidQuirkID) Error() string {
+	return fmt.Sprintf(`%s: "%s" is not a valid quirk ID`, r.Caller, r.QuirkID)
+}
+
+func (r ErrQuirkExisted) Error() string {
+	return fmt.Sprintf(`%s: the hero already has quirk "%s"`, r.Caller, r.QuirkID)
+}
+
+func (r ErrQuirkNotFound) Error() string {
+	return fmt.Sprintf(`%s: the hero does not have quirk "%s"`, r.Caller, r.QuirkID)
+}
@@ -0,0 +1,107 @@
+package roster
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func readRoster(t *testing.T) (*dstruct.Struct, *Roster) {
+	fileBytes, err := ioutil.ReadFile("../../sample_dson/persist.roster.json")
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+	roster, err := FromStruct(*file)
+	require.NoError(t, err)
+	return file, roster
+}
+
+func TestFromStruct(t *testing.T) {
+	_, roster := readRoster(t)
+
+	require.Len(t, roster.Heroes, 20)
+	hero := roster.FindHero("56")
+	require.NotNil(t, hero)
+	require.Equal(t, "Botin", hero.Actor.Name)
+	require.Equal(t, "man_at_arms", hero.Class)
+	require.Equal(t, 9, hero.ResolveXP)
+	require.Equal(t, float64(8), hero.Stress)
+	require.Equal(t, float64(37), hero.Actor.CurrentHP)
+	require.Equal(t, 1, hero.WeaponRank)
+	require.Len(t, hero.Quirks, 8)
+	require.Equal(t, "unquiet_mind", hero.Quirks[0].ID)
+	require.True(t, hero.Quirks[0].IsLocked)
+	require.Equal(t, []Skill{{"rampart", 0}, {"defender", 0}, {"retribution", 0}, {"bolster", 0}}, hero.Skills.Combat)
+	require.Equal(t, "ancestors_map", hero.Trinkets[0].ID)
+	require.Nil(t, roster.FindHero("-1"))
+}
+
+func TestToStruct_Untouched(t *testing.T) {
+	file, roster := readRoster(t)
+
+	actualFile, err := ToStruct(*roster)
+	require.NoError(t, err)
+	require.Equal(t, dstruct.EncodeStruct(*file), dstruct.EncodeStruct(*actualFile))
+}
+
+func TestToStruct_Edited(t *testing.T) {
+	_, roster := readRoster(t)
+
+	hero := roster.FindHero("56")
+	hero.Stress = 0
+	hero.Actor.CurrentHP = 12.5
+	hero.Quirks = append(hero.Quirks[1:], NewQuirk("clotter"))
+	hero.Skills.Combat[0].Level = 4
+
+	file, err := ToStruct(*roster)
+	require.NoError(t, err)
+	// going through the bytes makes sure that the embedded file is encoded properly
+	file, err = dstruct.ToStructuredFile(dstruct.EncodeStruct(*file))
+	require.NoError(t, err)
+	roster, err = FromStruct(*file)
+	require.NoError(t, err)
+
+	hero = roster.FindHero("56")
+	require.Equal(t, float64(0), hero.Stress)
+	require.Equal(t, 12.5, hero.Actor.CurrentHP)
+	require.Len(t, hero.Quirks, 8)
+	require.Equal(t, "bloodthirsty", hero.Quirks[0].ID)
+	require.Equal(t, "clotter", hero.Quirks[7].ID)
+	require.Equal(t, 4, hero.Skills.Combat[0].Level)
+	require.Equal(t, "Botin", hero.Actor.Name)
+}
+
+func TestHero_Level(t *testing.T) {
+	hero := Hero{}
+	for resolveXP, level := range map[int]int{0: 0, 1: 0, 2: 1, 9: 2, 20: 3, 81: 5, 82: 6, 500: 6} {
+		hero.ResolveXP = resolveXP
+		require.Equal(t, level, hero.Level(), "resolve XP %d", resolveXP)
+	}
+}
+
+func TestHero_Edit(t *testing.T) {
+	_, roster := readRoster(t)
+	hero := roster.FindHero("56")
+
+	require.ErrorAs(t, hero.SetStress(-1), &ErrOutOfRange{})
+	require.ErrorAs(t, hero.SetStress(MaxStress+1), &ErrOutOfRange{})
+	require.Equal(t, float64(8), hero.Stress)
+	require.NoError(t, hero.SetStress(MaxStress))
+	require.Equal(t, float64(MaxStress), hero.Stress)
+
+	require.ErrorAs(t, hero.SetCurrentHP(0), &ErrOutOfRange{})
+	require.Equal(t, float64(37), hero.Actor.CurrentHP)
+	require.NoError(t, hero.SetCurrentHP(20))
+	require.Equal(t, float64(20), hero.Actor.CurrentHP)
+
+	require.ErrorAs(t, hero.AddQuirk("unquiet_mind"), &ErrQuirkExisted{})
+	require.ErrorAs(t, hero.AddQuirk("not a quirk"), &ErrInvalidQuirkID{})
+	require.ErrorAs(t, hero.RemoveQuirk("clotter"), &ErrQuirkNotFound{})
+	require.NoError(t, hero.AddQuirk("clotter"))
+	require.NoError(t, hero.RemoveQuirk("unquiet_mind"))
+	require.Len(t, hero.Quirks, 8)
+	require.NotNil(t, hero.FindQuirk("clotter"))
+	require.Nil(t, hero.FindQuirk("unquiet_mind"))
+}
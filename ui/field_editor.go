@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+// FieldEditor browses the fields of a DSON file as a tree by their paths (see dson.Flatten), where objects and
+// embedded files are the branches, and edits the values in place. The file is only written when it is saved.
+type FieldEditor struct {
+	path       string
+	file       dstruct.Struct
+	flatFields []dson.FlatField
+	// current is the path of the object (or embedded file) whose fields are listed
+	current []string
+	cursor  int
+	// cursors are the cursors of the parents of current, to restore them when going back
+	cursors []int
+	height  int
+	// input is not nil while a value is edited
+	input   *TextInput
+	changed bool
+	// confirmingKey is the key that users pressed to leave with unsaved changes; pressing it again confirms
+	confirmingKey string
+	message       string
+	selector      FileSelector
+}
+
+func CreateFieldEditor(path string, file dstruct.Struct, selector FileSelector) FieldEditor {
+	return FieldEditor{
+		path:       path,
+		file:       file,
+		flatFields: dson.Flatten(file),
+		current:    []string{},
+		cursors:    []int{},
+		height:     selector.height,
+		selector:   selector,
+	}
+}
+
+// Children returns the fields that are directly held by the object (or embedded file) at path.
+func Children(flatFields []dson.FlatField, path []string) []dson.FlatField {
+	children := make([]dson.FlatField, 0)
+	for _, flatField := range flatFields {
+		if len(flatField.Path) == len(path)+1 && hasPrefix(flatField.Path, path) {
+			children = append(children, flatField)
+		}
+	}
+	return children
+}
+
+func hasPrefix(path []string, prefix []string) bool {
+	for i := range prefix {
+		if path[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// editText returns the text that a value is edited as: names and strings are written as is, and the rest as JSON.
+func editText(field dfield.DataField) string {
+	if text, ok := field.Inferences.Data.(string); ok {
+		return text
+	}
+	return dson.FormatValue(field.Inferences.DataType, field.Inferences.Data)
+}
+
+func (e FieldEditor) children() []dson.FlatField {
+	return Children(e.flatFields, e.current)
+}
+
+func (e FieldEditor) selected() (dson.FlatField, bool) {
+	children := e.children()
+	if len(children) == 0 {
+		return dson.FlatField{}, false
+	}
+	return children[e.cursor], true
+}
+
+func (e FieldEditor) apply(flatField dson.FlatField, text string) FieldEditor {
+	updatedFile, err := dstruct.UpdateField(
+		e.file,
+		flatField.Indexes,
+		func(field dfield.DataField) (dfield.DataField, error) { return dfield.SetDataByText(field, text) },
+	)
+	if err != nil {
+		e.message = "Error happened updating the field: " + err.Error()
+		return e
+	}
+	e.file = *updatedFile
+	e.flatFields = dson.Flatten(e.file)
+	e.changed = true
+	e.message = "Updated: " + flatField.PathString()
+	return e
+}
+
+// save backs up the file, and then overwrites it.
+func (e FieldEditor) save() FieldEditor {
+	snapshot, err := backup.CreateForFile(e.path)
+	if err != nil {
+		e.message = "Error happened backing up the file: " + err.Error()
+		return e
+	}
+	if err := ioutil.WriteFile(e.path, dstruct.EncodeStruct(e.file), 0644); err != nil {
+		e.message = "Error happened writing to file at: " + e.path
+		return e
+	}
+	e.changed = false
+	e.message = "Saved: " + e.path
+	if snapshot != nil {
+		e.message += " (backed up to: " + snapshot.Path + ")"
+	}
+	return e
+}
+
+func (e FieldEditor) enter() (tea.Model, tea.Cmd) {
+	flatField, ok := e.selected()
+	if !ok {
+		return e, nil
+	}
+	if flatField.IsContainer() {
+		e.current = flatField.Path
+		e.cursors = append(e.cursors, e.cursor)
+		e.cursor = 0
+		return e, nil
+	}
+	field := flatField.Field
+	switch field.Inferences.DataType {
+	case dfield.DataTypeBool:
+		return e.apply(flatField, strconv.FormatBool(!field.Inferences.Data.(bool))), nil
+	case dfield.DataTypeUnknown, dfield.DataTypeFileRaw:
+		e.message = "The field cannot be edited, since its data type is unknown."
+		return e, nil
+	}
+	e.input = &TextInput{DataType: dfield.InferDataType(field), Value: editText(field)}
+	return e, nil
+}
+
+// back goes to the parent of the current path, or to the file selector from the top, which needs to be confirmed
+// (pressing the same key again) if there are unsaved changes.
+func (e FieldEditor) back(key string, confirmed bool) (tea.Model, tea.Cmd) {
+	if len(e.current) > 0 {
+		e.current = e.current[:len(e.current)-1]
+		e.cursor = e.cursors[len(e.cursors)-1]
+		e.cursors = e.cursors[:len(e.cursors)-1]
+		return e, nil
+	}
+	if e.changed && !confirmed {
+		e.confirmingKey = key
+		e.message = "There are unsaved changes. Press " + key + " again to discard them, or ctrl+s to save."
+		return e, nil
+	}
+	return e.selector.changeDirectory(e.selector.cwd), nil
+}
+
+func (e FieldEditor) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		e.height = listHeight(msg.Height)
+		e.selector.height = e.height
+		return e, nil
+	case tea.KeyMsg:
+		if e.input != nil {
+			return e.updateInput(msg)
+		}
+		key := msg.String()
+		confirmed := e.confirmingKey == key
+		e.confirmingKey = ""
+		switch key {
+		case "ctrl+c":
+			return e, tea.Quit
+		case "q":
+			if e.changed && !confirmed {
+				e.confirmingKey = key
+				e.message = "There are unsaved changes. Press q again to quit without saving, or ctrl+s to save."
+				return e, nil
+			}
+			return e, tea.Quit
+		case "ctrl+s":
+			return e.save(), nil
+		case "up", "k":
+			e.cursor = moveCursor(e.cursor, -1, len(e.children()))
+		case "down", "j":
+			e.cursor = moveCursor(e.cursor, 1, len(e.children()))
+		case "enter", "right", "l":
+			return e.enter()
+		case "backspace", "left", "h":
+			return e.back(key, confirmed)
+		}
+	}
+	return e, nil
+}
+
+func (e FieldEditor) updateInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return e, tea.Quit
+	case "esc":
+		e.input = nil
+		e.message = ""
+		return e, nil
+	case "enter":
+		flatField, _ := e.selected()
+		text := e.input.Value
+		e.input = nil
+		return e.apply(flatField, text), nil
+	}
+	input := e.input.Update(msg)
+	e.input = &input
+	return e, nil
+}
+
+func (e FieldEditor) View() string {
+	title := "DARKEST SAVIOR\n\n"
+	title += "File: " + e.path
+	if e.changed {
+		title += " (unsaved)"
+	}
+	title += "\n"
+	title += "Path: " + strings.Join(e.current, ".") + "\n\n"
+
+	children := e.children()
+	rows := ""
+	start, end := visibleRange(e.cursor, len(children), e.height)
+	for i := start; i < end; i++ {
+		rows += cursorMark(i == e.cursor) + describe(e.flatFields, children[i]) + "\n"
+	}
+
+	footer := "\n"
+	if e.input != nil {
+		footer += fmt.Sprintf("New value (%s): %s\n", e.input.Hint(), e.input.View())
+		footer += "enter: apply • esc: cancel\n"
+	} else {
+		footer += e.message + "\n"
+		footer += "↑/↓: move • enter: open or edit • backspace: back • ctrl+s: save • q: quit\n"
+	}
+	return title + rows + footer
+}
+
+// describe renders a field as a row: objects and embedded files with the number of their fields, and values with
+// their data types.
+func describe(flatFields []dson.FlatField, flatField dson.FlatField) string {
+	key := flatField.Path[len(flatField.Path)-1]
+	if flatField.IsContainer() {
+		return fmt.Sprintf("%s/ (%d fields)", key, len(Children(flatFields, flatField.Path)))
+	}
+	field := flatField.Field
+	return fmt.Sprintf(
+		"%s (%s): %s",
+		key, dson.DescribeDataType(field), dson.FormatValue(field.Inferences.DataType, field.Inferences.Data),
+	)
+}
+
+func (e FieldEditor) Init() tea.Cmd {
+	return nil
+}
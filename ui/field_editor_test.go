@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thanhnguyen2187/darkest-savior/backup"
+	"github.com/thanhnguyen2187/darkest-savior/dson"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
+)
+
+func copySample(t *testing.T, folderPath string, name string) {
+	fileBytes, err := ioutil.ReadFile(filepath.Join("../sample_dson", name))
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(folderPath, name), fileBytes, 0644))
+}
+
+// press sends the keys to the model one by one, and returns the last command.
+func press(model tea.Model, keys ...tea.KeyMsg) (tea.Model, tea.Cmd) {
+	cmd := tea.Cmd(nil)
+	for _, key := range keys {
+		model, cmd = model.Update(key)
+	}
+	return model, cmd
+}
+
+func runes(text string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)}
+}
+
+var (
+	keyEnter     = tea.KeyMsg{Type: tea.KeyEnter}
+	keyBackspace = tea.KeyMsg{Type: tea.KeyBackspace}
+	keyDown      = tea.KeyMsg{Type: tea.KeyDown}
+	keyCtrlS     = tea.KeyMsg{Type: tea.KeyCtrlS}
+)
+
+// selectChild moves the cursor of the editor to the child with the key.
+func selectChild(t *testing.T, model tea.Model, key string) tea.Model {
+	editor := model.(FieldEditor)
+	for _, child := range editor.children() {
+		if child.Path[len(child.Path)-1] == key {
+			return model
+		}
+		model, _ = press(model, keyDown)
+	}
+	require.Failf(t, "child not found", `key "%s" within path "%v"`, key, editor.current)
+	return nil
+}
+
+func TestFieldEditor(t *testing.T) {
+	root := t.TempDir()
+	copySample(t, root, "persist.estate.json")
+	copySample(t, root, "persist.game.json")
+	filePath := filepath.Join(root, "persist.estate.json")
+
+	selector := CreateFileSelectorAt(root)
+	assert.Equal(t, CwdStateCorrect, selector.cwdState)
+	assert.Equal(t, []Entry{{Name: "persist.estate.json"}, {Name: "persist.game.json"}}, selector.entries)
+
+	model, _ := press(selector, keyEnter)
+	require.IsType(t, FieldEditor{}, model)
+	model, _ = press(model, keyEnter)
+	model = selectChild(t, model, "wallet")
+	model, _ = press(model, keyEnter, keyEnter)
+	model = selectChild(t, model, "amount")
+	assert.Contains(t, model.View(), "amount (int): 18275")
+
+	model, _ = press(model, keyEnter)
+	require.NotNil(t, model.(FieldEditor).input)
+	assert.Equal(t, "18275", model.(FieldEditor).input.Value)
+	model, _ = press(model, keyBackspace, keyBackspace, keyBackspace, keyBackspace, keyBackspace, runes("999"), keyEnter)
+	assert.Contains(t, model.View(), "amount (int): 999")
+	assert.Contains(t, model.View(), "(unsaved)")
+
+	// leaving with unsaved changes needs to be confirmed
+	model, cmd := press(model, runes("q"))
+	assert.Nil(t, cmd)
+	model, _ = press(model, keyCtrlS)
+	assert.False(t, model.(FieldEditor).changed)
+
+	fileBytes, err := ioutil.ReadFile(filePath)
+	require.NoError(t, err)
+	file, err := dstruct.ToStructuredFile(fileBytes)
+	require.NoError(t, err)
+	amounts := dson.Query(*file, "base_root.wallet.0.amount")
+	require.Len(t, amounts, 1)
+	assert.Equal(t, int32(999), amounts[0].Field.Inferences.Data)
+	snapshots, err := backup.List(filePath)
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+
+	// going back from the top leads to the file selector
+	model, _ = press(model, keyBackspace, keyBackspace, keyBackspace, keyBackspace)
+	require.IsType(t, FileSelector{}, model)
+	_, cmd = press(model, runes("q"))
+	assert.NotNil(t, cmd)
+}
+
+func TestFieldEditor_InvalidValue(t *testing.T) {
+	root := t.TempDir()
+	copySample(t, root, "persist.estate.json")
+
+	selector := CreateFileSelectorAt(root)
+	assert.Contains(t, selector.View(), "Please choose the correct save folder")
+	model, _ := press(selector, keyEnter, keyEnter)
+	model = selectChild(t, model, "wallet")
+	model, _ = press(model, keyEnter, keyEnter)
+	model = selectChild(t, model, "amount")
+	model, _ = press(model, keyEnter, runes("x"), keyEnter)
+	assert.True(t, strings.Contains(model.View(), "Error happened updating the field"))
+	assert.False(t, model.(FieldEditor).changed)
+}
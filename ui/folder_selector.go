@@ -2,15 +2,18 @@ package ui
 
 import (
 	"fmt"
-	"io/fs"
 	"io/ioutil"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/pkg/errors"
-	"github.com/samber/lo"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dstruct"
 	"github.com/thanhnguyen2187/darkest-savior/match"
+	"github.com/thanhnguyen2187/darkest-savior/profile"
 )
 
 const (
@@ -19,10 +22,21 @@ const (
 	CwdStateBlank     = ""
 )
 
-type FileSelector struct {
-	cwd      string
-	cwdState string
-}
+type (
+	FileSelector struct {
+		cwd      string
+		cwdState string
+		entries  []Entry
+		cursor   int
+		height   int
+		message  string
+	}
+	// Entry is a folder, or a DSON file within the current directory.
+	Entry struct {
+		Name  string
+		IsDir bool
+	}
+)
 
 func CreateFileSelector() FileSelector {
 	cwd, err := os.Getwd()
@@ -30,27 +44,64 @@ func CreateFileSelector() FileSelector {
 		err := errors.Wrap(err, "CreateFileSelector get current working directory error")
 		log.Panic(err)
 	}
-	return FileSelector{
-		cwd:      cwd,
-		cwdState: "",
-	}
+	return CreateFileSelectorAt(cwd)
 }
 
-type FileName string
+// CreateFileSelectorAt creates a file selector that starts from the folder at path.
+func CreateFileSelectorAt(path string) FileSelector {
+	s := FileSelector{
+		cwd:      path,
+		cwdState: CwdStateBlank,
+		height:   defaultHeight,
+	}
+	return s.changeDirectory(path)
+}
 
-func ReadDirectory(path string) []FileName {
+// ReadDirectory lists the folders (except the hidden ones, like the backups), and then the DSON files within the
+// folder at path, both sorted by their names.
+func ReadDirectory(path string) ([]Entry, error) {
 	files, err := ioutil.ReadDir(path)
 	if err != nil {
-		log.Fatal(err)
+		err := errors.Wrap(err, "ui.ReadDirectory error")
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(files))
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") {
+			continue
+		}
+		if file.IsDir() {
+			entries = append(entries, Entry{Name: file.Name(), IsDir: true})
+			continue
+		}
+		if filepath.Ext(file.Name()) == ".json" && profile.IsDSONFile(filepath.Join(path, file.Name())) {
+			entries = append(entries, Entry{Name: file.Name()})
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].IsDir && !entries[j].IsDir })
+	return entries, nil
+}
+
+// inspectDirectory tells whether the folder is a profile (see profile.IsProfileFolder).
+func inspectDirectory(path string) string {
+	if profile.IsProfileFolder(path) {
+		return CwdStateCorrect
 	}
+	return CwdStateIncorrect
+}
 
-	fileNames := lo.Map(
-		files,
-		func(t fs.FileInfo, _ int) FileName {
-			return FileName(t.Name())
-		},
-	)
-	return fileNames
+func (s FileSelector) changeDirectory(path string) FileSelector {
+	entries, err := ReadDirectory(path)
+	if err != nil {
+		s.message = "Error happened reading folder: " + path
+		return s
+	}
+	s.cwd = path
+	s.cwdState = inspectDirectory(path)
+	s.entries = entries
+	s.cursor = 0
+	s.message = ""
+	return s
 }
 
 func (s FileSelector) View() string {
@@ -81,12 +132,62 @@ func (s FileSelector) View() string {
 		).
 		Result()
 	msg := msgAny.(string)
-	output += msg
+	output += msg + "\n\n"
 
+	if len(s.entries) == 0 {
+		output += "  (no folder or DSON file)\n"
+	}
+	start, end := visibleRange(s.cursor, len(s.entries), s.height)
+	for i := start; i < end; i++ {
+		entry := s.entries[i]
+		name := entry.Name
+		if entry.IsDir {
+			name += string(filepath.Separator)
+		}
+		output += cursorMark(i == s.cursor) + name + "\n"
+	}
+
+	output += "\n" + s.message + "\n"
+	output += "↑/↓: move • enter: open • backspace: parent folder • q: quit\n"
 	return output
 }
 
 func (s FileSelector) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		s.height = listHeight(msg.Height)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return s, tea.Quit
+		case "up", "k":
+			s.cursor = moveCursor(s.cursor, -1, len(s.entries))
+		case "down", "j":
+			s.cursor = moveCursor(s.cursor, 1, len(s.entries))
+		case "backspace", "left", "h":
+			return s.changeDirectory(filepath.Dir(s.cwd)), nil
+		case "enter", "right", "l":
+			if len(s.entries) == 0 {
+				return s, nil
+			}
+			entry := s.entries[s.cursor]
+			path := filepath.Join(s.cwd, entry.Name)
+			if entry.IsDir {
+				return s.changeDirectory(path), nil
+			}
+			fileBytes, err := ioutil.ReadFile(path)
+			if err != nil {
+				s.message = "Error happened reading file at: " + path
+				return s, nil
+			}
+			file, err := dstruct.ToStructuredFile(fileBytes)
+			if err != nil {
+				s.message = "Error happened decoding DSON file at: " + path
+				return s, nil
+			}
+			return CreateFieldEditor(path, *file, s), nil
+		}
+	}
 	return s, nil
 }
 
@@ -0,0 +1,48 @@
+package ui
+
+// defaultHeight is the number of list rows that are shown before the size of the terminal is known.
+const defaultHeight = 20
+
+// reservedHeight is the number of lines that are taken by the title, the status, and the help of each screen.
+const reservedHeight = 8
+
+func listHeight(terminalHeight int) int {
+	if terminalHeight-reservedHeight < 1 {
+		return 1
+	}
+	return terminalHeight - reservedHeight
+}
+
+// moveCursor moves the cursor by delta within a list of count items, and stops at both ends.
+func moveCursor(cursor int, delta int, count int) int {
+	cursor += delta
+	if cursor >= count {
+		cursor = count - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
+
+// visibleRange returns the items that fit the height, and keeps the cursor at the middle where possible.
+func visibleRange(cursor int, count int, height int) (start int, end int) {
+	if count <= height {
+		return 0, count
+	}
+	start = cursor - height/2
+	if start < 0 {
+		start = 0
+	}
+	if start+height > count {
+		start = count - height
+	}
+	return start, start + height
+}
+
+func cursorMark(selected bool) string {
+	if selected {
+		return "> "
+	}
+	return "  "
+}
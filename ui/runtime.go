@@ -4,9 +4,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-func Start() {
-	folderSelector := CreateFileSelector()
-	if err := tea.NewProgram(&folderSelector).Start(); err != nil {
-		panic(err)
-	}
+// Start runs the interactive editor from the current working directory until users quit.
+func Start() error {
+	fileSelector := CreateFileSelector()
+	return tea.NewProgram(fileSelector, tea.WithAltScreen()).Start()
 }
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/thanhnguyen2187/darkest-savior/dson/dfield"
+)
+
+// TextInput is a single line input for the value of a field, which only accepts the characters that the data type
+// allows.
+type TextInput struct {
+	DataType dfield.DataType
+	Value    string
+}
+
+// inputHintByDataType tells users how to write a value of each data type.
+var inputHintByDataType = map[dfield.DataType]string{
+	dfield.DataTypeChar:         "a single character",
+	dfield.DataTypeInt:          "an integer, or a name like ###crusader",
+	dfield.DataTypeFloat:        "a number",
+	dfield.DataTypeString:       "text",
+	dfield.DataTypeIntVector:    "a JSON array of integers or names, like [1, \"###crusader\"]",
+	dfield.DataTypeHybridVector: "a JSON array of integers or names, like [1, \"###crusader\"]",
+	dfield.DataTypeFloatVector:  "a JSON array of numbers, like [0.5, 2]",
+	dfield.DataTypeStringVector: "a JSON array of strings, like [\"a\", \"b\"]",
+	dfield.DataTypeTwoInt:       "a JSON array of two integers, like [1, 2]",
+	dfield.DataTypeTwoBool:      "a JSON array of two booleans, like [true, false]",
+}
+
+func (i TextInput) Hint() string {
+	return inputHintByDataType[i.DataType]
+}
+
+func (i TextInput) accepts(r rune) bool {
+	switch i.DataType {
+	case dfield.DataTypeChar:
+		return len(i.Value) == 0 && 0x20 <= r && r <= 0x7E
+	case dfield.DataTypeFloat:
+		return strings.ContainsRune("0123456789.-+eE", r)
+	}
+	return true
+}
+
+// Update handles typing and deleting characters; other keys are left to the editor.
+func (i TextInput) Update(msg tea.KeyMsg) TextInput {
+	switch msg.Type {
+	case tea.KeyBackspace:
+		runes := []rune(i.Value)
+		if len(runes) > 0 {
+			i.Value = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		if i.accepts(' ') {
+			i.Value += " "
+		}
+	case tea.KeyRunes:
+		for _, r := range msg.Runes {
+			if i.accepts(r) {
+				i.Value += string(r)
+			}
+		}
+	}
+	return i
+}
+
+func (i TextInput) View() string {
+	return i.Value + "█"
+}